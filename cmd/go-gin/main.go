@@ -1,38 +1,48 @@
 package main
 
 import (
-	"log"
-	"net/http"
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/braden0236/playground/pkg/go-gin/app"
 	"github.com/braden0236/playground/pkg/go-gin/config"
-	"github.com/braden0236/playground/pkg/go-gin/metric"
 	"github.com/braden0236/playground/pkg/go-gin/server"
+	"github.com/braden0236/playground/pkg/logging"
+	"github.com/oklog/run"
 )
 
 func main() {
 
 	conf, err := config.Init()
 	if err != nil {
-		log.Printf("Failed to initialize configuration: %v", err)
+		slog.Error("failed to initialize configuration", "error", err)
 		os.Exit(100)
 	}
 
-	metrics := metric.NewMetrics(
-		metric.WithIgnoredMethods(http.MethodOptions, http.MethodHead),
-	)
+	if err := logging.Setup(logging.Config{Format: conf.Log.Format, Level: conf.Log.Level}); err != nil {
+		slog.Error("failed to initialize logging", "error", err)
+		os.Exit(100)
+	}
+
+	a, err := app.New(conf)
+	if err != nil {
+		slog.Error("failed to build server", "error", err)
+		os.Exit(102)
+	}
 
-	server := server.NewServer(metrics)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	go func() {
-		if err := server.Run(conf.Server); err != nil{
-			log.Printf("Server start failed: %v", err)
-			os.Exit(101)
-		}
-	}()
+	var g run.Group
+	g.Add(a.Server.RunFunc(conf.Server))
+	a.AddActors(&g)
+	g.Add(server.WaitForShutdown(ctx, stop))
 
-	if err := server.WaitForShutdown(nil); err != nil {
-		log.Printf("Shutdown error: %v", err)
+	if err := g.Run(); err != nil {
+		slog.Error("server exited with error", "error", err)
+		os.Exit(101)
 	}
-
 }
@@ -3,7 +3,8 @@ package main
 import (
 	"context"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,7 +13,13 @@ import (
 	"github.com/braden0236/playground/internal/go-grpc/client"
 	"github.com/braden0236/playground/internal/go-grpc/config"
 	"github.com/braden0236/playground/internal/go-grpc/server"
+	"github.com/braden0236/playground/pkg/admin"
+	"github.com/braden0236/playground/pkg/go-gin/app"
+	ginconfig "github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/go-gin/metric"
+	"github.com/braden0236/playground/pkg/logging"
 	"github.com/oklog/run"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var Conf *config.Config
@@ -20,11 +27,15 @@ var Conf *config.Config
 func init() {
 	conf, err := config.Init()
 	if err != nil {
-		log.Printf("Failed to initialize configuration: %v", err)
+		slog.Error("failed to initialize configuration", "error", err)
+		os.Exit(100)
+	}
+	if err := logging.Setup(logging.Config{Format: conf.Log.Format, Level: conf.Log.Level}); err != nil {
+		slog.Error("failed to initialize logging", "error", err)
 		os.Exit(100)
 	}
 	Conf = conf
-	log.Printf("Loaded config: %+v", Conf)
+	slog.Debug("loaded config", "config", admin.Redact(Conf))
 }
 
 func main() {
@@ -34,10 +45,12 @@ func main() {
 	switch *mode {
 	case "server":
 		startServer()
+	case "combined":
+		startCombined()
 	case "client":
 		startClient()
 	default:
-		log.Println("Usage: go run main.go --mode [server|client]")
+		fmt.Fprintln(os.Stderr, "Usage: go run main.go --mode [server|combined|client]")
 	}
 }
 
@@ -46,33 +59,111 @@ func startServer() {
 
 	srv, err := server.NewGRPCServer(Conf.Server)
 	if err != nil {
-		log.Fatalf("❌ Failed to init gRPC server: %v", err)
+		fatal("failed to init gRPC server", err)
 	}
 
 	var g run.Group
+	if Conf.Server.GRPCWeb.Enabled {
+		// Added first so it is interrupted, and drained, before srv.
+		webSrv, err := server.NewGRPCWebServer(Conf.Server, srv)
+		if err != nil {
+			fatal("failed to init gRPC-Web server", err)
+		}
+		g.Add(webSrv.RunFunc())
+	}
 	g.Add(srv.RunFunc())
+	g.Add(srv.TrackerRunFunc())
+	addMetricsServer(&g, newAdmin(srv))
+	g.Add(server.WaitForShutdown(ctx, stop))
+
+	if err := g.Run(); err != nil {
+		slog.Error("exited with error", "error", err)
+	}
+}
 
-	if Conf.Server.Metrics.Enabled {
-		metricsSrv := server.NewMetricsServer(Conf.Server)
-		metricsSrv.Register("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("ok"))
-		}))
-		g.Add(metricsSrv.RunFunc())
+// startCombined serves gRPC and the gin HTTP API on Conf.Server.Address.
+func startCombined() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	grpcSrv, err := server.NewGRPCServer(Conf.Server)
+	if err != nil {
+		fatal("failed to init gRPC server", err)
+	}
+
+	webConf, err := ginconfig.Init()
+	if err != nil {
+		fatal("failed to init HTTP config", err)
 	}
 
+	adm := newAdmin(grpcSrv)
+	web, err := app.New(webConf,
+		app.WithAdmin(adm),
+		// Shares the global registry, which the metrics server serves, so
+		// /metrics needs the same credentials as there.
+		app.WithMetricOptions(
+			metric.WithRegistry(prometheus.DefaultRegisterer, prometheus.DefaultGatherer),
+			metric.WithBasicAuth(Conf.Server.Metrics.Auth.Username, Conf.Server.Metrics.Auth.Password),
+		),
+	)
+	if err != nil {
+		fatal("failed to init HTTP server", err)
+	}
+
+	srv, err := server.NewCombinedServer(Conf.Server, grpcSrv, web.Server)
+	if err != nil {
+		fatal("failed to init combined server", err)
+	}
+
+	var g run.Group
+	g.Add(srv.RunFunc())
+	g.Add(grpcSrv.TrackerRunFunc())
+	web.AddActors(&g)
+	addMetricsServer(&g, adm)
 	g.Add(server.WaitForShutdown(ctx, stop))
 
 	if err := g.Run(); err != nil {
-		log.Printf("💥 Exited with error: %v", err)
+		slog.Error("exited with error", "error", err)
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// newAdmin protects the admin routes with the metrics credentials; without
+// them the routes are not served.
+func newAdmin(srv *server.Server) *admin.Admin {
+	return admin.New(
+		admin.WithConfig(Conf),
+		admin.WithMaintenance(srv.Maintenance()),
+		admin.WithBasicAuth(Conf.Server.Metrics.Auth.Username, Conf.Server.Metrics.Auth.Password),
+	)
+}
+
+func addMetricsServer(g *run.Group, adm *admin.Admin) {
+	if !Conf.Server.Metrics.Enabled {
+		return
+	}
+
+	metricsSrv := server.NewMetricsServer(Conf.Server)
+	metricsSrv.Register("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}))
+	if adm.Enabled() {
+		metricsSrv.Register(admin.Prefix+"/", adm.Handler())
+	} else {
+		slog.Warn("admin routes disabled, no metrics credentials set")
 	}
+	g.Add(metricsSrv.RunFunc())
 }
 
 func startClient() {
 
 	client, err := client.New(Conf.Client)
 	if err != nil {
-		log.Fatalf("Failed to create client: %v", err)
+		fatal("failed to create client", err)
 	}
 	defer client.Close()
 
@@ -0,0 +1,43 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusLabelCreated   Status = "LABEL_CREATED"
+	StatusInTransit      Status = "IN_TRANSIT"
+	StatusOutForDelivery Status = "OUT_FOR_DELIVERY"
+	StatusDelivered      Status = "DELIVERED"
+)
+
+var ErrUnknownTrackingNumber = errors.New("carrier: unknown tracking number")
+
+type Event struct {
+	Status      Status
+	Location    string
+	Description string
+	Time        time.Time
+}
+
+// Carrier adapts a shipping provider's tracking API. Register is called once
+// when a shipment is attached to an order; Track returns every event known so
+// far, oldest first.
+type Carrier interface {
+	Name() string
+	Register(ctx context.Context, trackingNumber string) error
+	Track(ctx context.Context, trackingNumber string) ([]Event, error)
+}
+
+func Delivered(events []Event) bool {
+	for _, e := range events {
+		if e.Status == StatusDelivered {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package carrier
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const FakeName = "fake"
+
+var fakeRoute = []Event{
+	{Status: StatusLabelCreated, Location: "Origin facility", Description: "Shipping label created"},
+	{Status: StatusInTransit, Location: "Regional hub", Description: "Arrived at sorting center"},
+	{Status: StatusInTransit, Location: "Destination hub", Description: "Departed sorting center"},
+	{Status: StatusOutForDelivery, Location: "Local depot", Description: "Out for delivery"},
+	{Status: StatusDelivered, Location: "Recipient address", Description: "Delivered"},
+}
+
+// Fake is an in-memory Carrier that moves every parcel one step along a fixed
+// route each time step elapses after registration.
+type Fake struct {
+	step time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	started map[string]time.Time
+}
+
+func NewFake(step time.Duration) *Fake {
+	return &Fake{
+		step:    step,
+		now:     time.Now,
+		started: make(map[string]time.Time),
+	}
+}
+
+func (f *Fake) Name() string {
+	return FakeName
+}
+
+func (f *Fake) Register(ctx context.Context, trackingNumber string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.started[trackingNumber]; !ok {
+		f.started[trackingNumber] = f.now()
+	}
+	return nil
+}
+
+func (f *Fake) Track(ctx context.Context, trackingNumber string) ([]Event, error) {
+	f.mu.Lock()
+	start, ok := f.started[trackingNumber]
+	f.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownTrackingNumber
+	}
+
+	reached := 1
+	if f.step > 0 {
+		reached += int(f.now().Sub(start) / f.step)
+	} else {
+		reached = len(fakeRoute)
+	}
+	if reached > len(fakeRoute) {
+		reached = len(fakeRoute)
+	}
+
+	events := make([]Event, 0, reached)
+	for i := 0; i < reached; i++ {
+		e := fakeRoute[i]
+		e.Time = start.Add(time.Duration(i) * f.step)
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,80 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFakeTrack(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		step    time.Duration
+		elapsed time.Duration
+		want    []Status
+	}{
+		{"just registered", time.Hour, 0, []Status{StatusLabelCreated}},
+		{"one step later", time.Hour, time.Hour, []Status{StatusLabelCreated, StatusInTransit}},
+		{"between steps", time.Hour, 150 * time.Minute, []Status{StatusLabelCreated, StatusInTransit, StatusInTransit}},
+		{"past the end of the route", time.Hour, 100 * time.Hour, []Status{StatusLabelCreated, StatusInTransit, StatusInTransit, StatusOutForDelivery, StatusDelivered}},
+		{"no step delivers at once", 0, 0, []Status{StatusLabelCreated, StatusInTransit, StatusInTransit, StatusOutForDelivery, StatusDelivered}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			f := NewFake(tt.step)
+			f.now = func() time.Time { return now }
+
+			if err := f.Register(context.Background(), "T1"); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			now = now.Add(tt.elapsed)
+
+			events, err := f.Track(context.Background(), "T1")
+			if err != nil {
+				t.Fatalf("Track() error = %v", err)
+			}
+			if len(events) != len(tt.want) {
+				t.Fatalf("Track() returned %d events, want %d", len(events), len(tt.want))
+			}
+			for i, e := range events {
+				if e.Status != tt.want[i] {
+					t.Errorf("event %d status = %s, want %s", i, e.Status, tt.want[i])
+				}
+				if want := start.Add(time.Duration(i) * tt.step); !e.Time.Equal(want) {
+					t.Errorf("event %d time = %v, want %v", i, e.Time, want)
+				}
+			}
+			if got, want := Delivered(events), tt.want[len(tt.want)-1] == StatusDelivered; got != want {
+				t.Errorf("Delivered() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFakeRegisterKeepsStart(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(time.Hour)
+	f.now = func() time.Time { return now }
+
+	_ = f.Register(context.Background(), "T1")
+	now = now.Add(2 * time.Hour)
+	_ = f.Register(context.Background(), "T1")
+
+	events, err := f.Track(context.Background(), "T1")
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Track() returned %d events after registering twice, want 3", len(events))
+	}
+}
+
+func TestFakeTrackUnknown(t *testing.T) {
+	_, err := NewFake(time.Hour).Track(context.Background(), "missing")
+	if !errors.Is(err, ErrUnknownTrackingNumber) {
+		t.Fatalf("Track() error = %v, want %v", err, ErrUnknownTrackingNumber)
+	}
+}
@@ -3,13 +3,14 @@ package client
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/braden0236/playground/internal/go-grpc/config"
-	"github.com/braden0236/playground/internal/go-grpc/tls"
 	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/requestid"
+	"github.com/braden0236/playground/pkg/tlsutil"
 	_ "github.com/braden0236/playground/internal/go-grpc/dns"
 
 	"google.golang.org/grpc"
@@ -44,9 +45,12 @@ func New(cfg config.Client) (*Client, error) {
 				PermitWithoutStream: true,
 			},
 		),
-		// grpc.WithChainUnaryInterceptor(
-		// 	middleware.ClientUserHeaderInterceptor,
-		// ),
+		grpc.WithChainUnaryInterceptor(
+			requestid.UnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			requestid.StreamClientInterceptor(),
+		),
 	}
 
 	if cfg.EnableRoundRobin {
@@ -54,7 +58,7 @@ func New(cfg config.Client) (*Client, error) {
 	}
 
 	if cfg.UseTLS {
-		tlsConfig, err := tls.BuildConfig(cfg)
+		tlsConfig, err := tlsutil.BuildClientConfig(cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -108,7 +112,7 @@ func (c *Client) SendSingleRequest(workerID int) {
 
 	resp, err := c.GetOrder(context.Background(), orderID)
 	if err != nil {
-		log.Printf("[Worker %d] GetOrder failed: %v", workerID, err)
+		slog.Warn("GetOrder failed", "worker", workerID, "error", err)
 		time.Sleep(1 * time.Second)
 		return
 	}
@@ -138,13 +142,13 @@ func (c *Client) PrintStats() {
 		mu.Unlock()
 
 		if localTotal == 0 {
-			log.Println("Stats: No data")
+			slog.Info("stats: no data")
 			continue
 		}
 
 		for desc, cnt := range localCounter {
 			percent := float64(cnt) / float64(localTotal) * 100
-			log.Printf("Description: %s, Count: %d, Percent: %.2f%%\n", desc, cnt, percent)
+			slog.Info("stats", "description", desc, "count", cnt, "percent", fmt.Sprintf("%.2f", percent))
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ func WithPrefix(prefix string) Option {
 type Config struct {
 	Client Client
 	Server Server
+	Log    Log
 }
 
 type Client struct {
@@ -48,9 +50,13 @@ type Server struct {
 	CertFile       string
 	KeyFile        string
 	CaFile         string
-	ClientCertAuth bool // require client cert for mTLS
+	ClientCertAuth bool          // require client cert for mTLS
+	DrainDelay     time.Duration // combined mode: how long to serve as not ready before shutting down
 
 	Metrics
+	Shipping   Shipping
+	Operations Operations
+	GRPCWeb    GRPCWeb
 }
 
 func (s Server) GetCertFile() string   { return s.CertFile }
@@ -68,6 +74,26 @@ type Metrics struct {
 	}
 }
 
+type GRPCWeb struct {
+	Enabled        bool
+	Address        string   // HTTP/1.1 listener in server mode; combined mode serves it on Address
+	AllowedOrigins []string // CORS origins, "*" allows any; required when enabled
+}
+
+type Log struct {
+	Format string // json | text
+	Level  string // debug | info | warn | error
+}
+
+type Shipping struct {
+	PollInterval    time.Duration // how often undelivered shipments are polled
+	FakeCarrierStep time.Duration // time the fake carrier takes per tracking event
+}
+
+type Operations struct {
+	Retention time.Duration // how long finished bulk operations stay readable
+}
+
 func Init(opts ...Option) (*Config, error) {
 
 	options := &Options{}
@@ -102,6 +128,10 @@ func Init(opts ...Option) (*Config, error) {
 		cfg.Server.CaFile = s
 	}
 
+	if d := viper.GetDuration("server.drain_delay"); d > 0 {
+		cfg.Server.DrainDelay = d
+	}
+
 	cfg.Server.Metrics.Enabled = viper.GetBool("server.metrics.enabled")
 	if s := viper.GetString("server.metrics.address"); s != "" {
 		cfg.Server.Metrics.Address = s
@@ -116,6 +146,35 @@ func Init(opts ...Option) (*Config, error) {
 		cfg.Server.Metrics.Auth.Password = s
 	}
 
+	if d := viper.GetDuration("server.shipping.poll_interval"); d > 0 {
+		cfg.Server.Shipping.PollInterval = d
+	}
+	if d := viper.GetDuration("server.shipping.fake_carrier_step"); d > 0 {
+		cfg.Server.Shipping.FakeCarrierStep = d
+	}
+
+	if d := viper.GetDuration("server.operations.retention"); d > 0 {
+		cfg.Server.Operations.Retention = d
+	}
+
+	cfg.Server.GRPCWeb.Enabled = viper.GetBool("server.grpc_web.enabled")
+	if s := viper.GetString("server.grpc_web.address"); s != "" {
+		cfg.Server.GRPCWeb.Address = s
+	}
+	if s := viper.GetString("server.grpc_web.allowed_origins"); s != "" {
+		cfg.Server.GRPCWeb.AllowedOrigins = nil
+		for _, origin := range strings.Split(s, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.Server.GRPCWeb.AllowedOrigins = append(cfg.Server.GRPCWeb.AllowedOrigins, origin)
+			}
+		}
+	}
+	// Browsers send an Origin with every gRPC-Web call, so without allowed
+	// origins none of them would get through.
+	if cfg.Server.GRPCWeb.Enabled && len(cfg.Server.GRPCWeb.AllowedOrigins) == 0 {
+		return nil, fmt.Errorf("SERVER_GRPC_WEB_ALLOWED_ORIGINS must be set when gRPC-Web is enabled, \"*\" allows any origin")
+	}
+
 	cfg.Client.UseTLS = viper.GetBool("client.use_tls")
 	if s := viper.GetString("client.address"); s != "" {
 		cfg.Client.Address = s
@@ -147,6 +206,13 @@ func Init(opts ...Option) (*Config, error) {
 
 	cfg.Client.EnableRoundRobin = viper.GetBool("client.enable_round_robin")
 
+	if s := viper.GetString("log.format"); s != "" {
+		cfg.Log.Format = s
+	}
+	if s := viper.GetString("log.level"); s != "" {
+		cfg.Log.Level = s
+	}
+
 	return cfg, nil
 }
 
@@ -175,6 +241,20 @@ func defaultConfig() *Config {
 				Address: ":9092",
 				Path:    "/metrics",
 			},
+			Shipping: Shipping{
+				PollInterval:    10 * time.Second,
+				FakeCarrierStep: 30 * time.Second,
+			},
+			Operations: Operations{
+				Retention: time.Hour,
+			},
+			GRPCWeb: GRPCWeb{
+				Address: ":9093",
+			},
+		},
+		Log: Log{
+			Format: "json",
+			Level:  "info",
 		},
 	}
 }
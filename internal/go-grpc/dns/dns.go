@@ -2,7 +2,7 @@ package dns
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net"
 	"strings"
 	"time"
@@ -65,7 +65,7 @@ func (r *dnsResolver) resolve() {
 
 	addrs, err := net.LookupHost(h)
 	if err != nil {
-		log.Printf("[resolver] DNS lookup failed: %v", err)
+		slog.Warn("DNS lookup failed", "component", "resolver", "error", err)
 		return
 	}
 
@@ -79,13 +79,13 @@ func (r *dnsResolver) resolve() {
 	}
 
 	if len(resolvedAddrs) == 0 {
-		log.Printf("[resolver] no available IP")
+		slog.Warn("no available IP", "component", "resolver")
 		return
 	}
 
 	err = r.cc.UpdateState(resolver.State{Addresses: resolvedAddrs})
 	if err != nil {
-		log.Printf("[resolver] UpdateState failed: %v", err)
+		slog.Warn("UpdateState failed", "component", "resolver", "error", err)
 	}
 }
 
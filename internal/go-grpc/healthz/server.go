@@ -9,26 +9,79 @@ import (
 
 type Server struct {
 	grpc_health_v1.UnimplementedHealthServer
-	mu    sync.RWMutex
-	ready bool
+	mu          sync.RWMutex
+	ready       bool
+	maintenance bool
+	changed     chan struct{} // closed and replaced on every change, for Watch
 }
 
 func New() *Server {
-	return &Server{ready: true}
+	return &Server{ready: true, changed: make(chan struct{})}
 }
 
 func (s *Server) SetNotReady() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ready = false
+	s.notify()
+}
+
+// SetMaintenance reports NOT_SERVING while on without affecting the
+// shutdown state set by SetNotReady.
+func (s *Server) SetMaintenance(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maintenance = on
+	s.notify()
+}
+
+// notify must be called with mu held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
 }
 
 func (s *Server) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	status, _ := s.status()
+	return &grpc_health_v1.HealthCheckResponse{Status: status}, nil
+}
+
+// Watch sends the current status and then every change until the client
+// goes away. Once SetNotReady is called it ends after reporting
+// NOT_SERVING, so it doesn't hold up a graceful stop.
+func (s *Server) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	last := grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+	for {
+		status, changed := s.status()
+		if status != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			last = status
+		}
+		if s.stopping() {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-changed:
+		}
+	}
+}
+
+func (s *Server) stopping() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.ready
+}
+
+func (s *Server) status() (grpc_health_v1.HealthCheckResponse_ServingStatus, <-chan struct{}) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.ready {
-		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+	if s.ready && !s.maintenance {
+		return grpc_health_v1.HealthCheckResponse_SERVING, s.changed
 	}
-	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING, s.changed
 }
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/braden0236/playground/internal/go-grpc/config"
+)
+
+const combinedStopTimeout = 10 * time.Second
+
+// WebServer is the HTTP side of a CombinedServer, e.g. the gin server from
+// pkg/go-gin/server.
+type WebServer interface {
+	http.Handler
+	Start(ctx context.Context) error
+	SetNotReady()
+	Shutdown(ctx context.Context) error
+	// RegisterOnShutdown registers the functions to run when srv starts
+	// shutting down.
+	RegisterOnShutdown(srv *http.Server)
+}
+
+// CombinedServer serves gRPC and HTTP on a single listener. Requests with an
+// application/grpc content type over HTTP/2 go to the gRPC server, gRPC-Web
+// calls to the gRPC server through the gRPC-Web wrapper when enabled; all
+// other traffic, HTTP/1.1 or HTTP/2 (h2c or TLS), goes to the web handler.
+type CombinedServer struct {
+	grpc    *Server
+	grpcWeb *grpcWeb
+	web     WebServer
+	server  *http.Server
+	cfg     config.Server
+}
+
+func NewCombinedServer(cfg config.Server, grpcSrv *Server, web WebServer) (*CombinedServer, error) {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	s := &CombinedServer{
+		grpc: grpcSrv,
+		web:  web,
+		cfg:  cfg,
+	}
+	if cfg.GRPCWeb.Enabled {
+		s.grpcWeb = newGRPCWeb(grpcSrv, cfg.GRPCWeb)
+	}
+	s.server = &http.Server{
+		Addr:      cfg.Address,
+		Handler:   s,
+		Protocols: protocols,
+	}
+	web.RegisterOnShutdown(s.server)
+
+	if cfg.UseTLS {
+		tlsConfig, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+		s.server.TLSConfig = tlsConfig
+	}
+
+	return s, nil
+}
+
+func (s *CombinedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Checked first: application/grpc-web also has the application/grpc
+	// prefix.
+	if s.grpcWeb != nil && s.grpcWeb.handles(r) {
+		s.grpcWeb.ServeHTTP(w, r)
+		return
+	}
+	if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		s.grpc.grpcServer.ServeHTTP(w, r)
+		return
+	}
+	s.web.ServeHTTP(w, r)
+}
+
+func (s *CombinedServer) Run() error {
+	if err := s.web.Start(context.Background()); err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", s.cfg.Address)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("combined gRPC/HTTP server listening", "address", lis.Addr().String(), "tls", s.cfg.UseTLS)
+	if s.cfg.UseTLS {
+		err = s.server.ServeTLS(lis, "", "")
+	} else {
+		err = s.server.Serve(lis)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Drain reports NOT_SERVING on gRPC health and not ready on the web
+// server, then keeps serving for the configured drain delay so load
+// balancers stop routing here before the listener closes.
+func (s *CombinedServer) Drain() {
+	s.grpc.healthServer.SetNotReady()
+	s.web.SetNotReady()
+
+	if s.cfg.DrainDelay > 0 {
+		slog.Info("draining before shutdown", "delay", s.cfg.DrainDelay)
+		time.Sleep(s.cfg.DrainDelay)
+	}
+}
+
+// Stop drains both protocols: the HTTP server stops accepting and waits for
+// in-flight requests, gRPC streams included, then the web server's
+// shutdown hooks run and the gRPC server is stopped. The gRPC server is only
+// stopped gracefully once no call is left in flight: GracefulStop panics on
+// calls still running through its ServeHTTP.
+func (s *CombinedServer) Stop(ctx context.Context) error {
+	slog.Info("shutting down combined server gracefully")
+	s.web.SetNotReady()
+	s.grpc.prepareStop()
+
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		slog.Error("combined server shutdown failed, closing open connections", "error", err)
+		_ = s.server.Close()
+	}
+
+	if werr := s.web.Shutdown(ctx); werr != nil {
+		slog.Error("web server shutdown failed", "error", werr)
+		if err == nil {
+			err = werr
+		}
+	}
+
+	if err != nil {
+		s.grpc.grpcServer.Stop()
+	} else {
+		s.grpc.grpcServer.GracefulStop()
+	}
+	return err
+}
+
+func (s *CombinedServer) RunFunc() (func() error, func(error)) {
+	return func() error {
+			return s.Run()
+		}, func(err error) {
+			s.Drain()
+			ctx, cancel := context.WithTimeout(context.Background(), combinedStopTimeout)
+			defer cancel()
+			_ = s.Stop(ctx)
+		}
+}
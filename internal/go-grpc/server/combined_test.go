@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/braden0236/playground/internal/go-grpc/config"
+	"github.com/braden0236/playground/internal/go-grpc/healthz"
+	"github.com/braden0236/playground/internal/go-grpc/server/operations"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakeWeb stands in for the gin server.
+type fakeWeb struct {
+	served     atomic.Int32
+	notReady   atomic.Bool
+	shutdown   atomic.Bool
+	onShutdown chan struct{}
+}
+
+func newFakeWeb() *fakeWeb {
+	return &fakeWeb{onShutdown: make(chan struct{})}
+}
+
+func (w *fakeWeb) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	w.served.Add(1)
+	rw.WriteHeader(http.StatusTeapot)
+}
+
+func (w *fakeWeb) Start(context.Context) error { return nil }
+func (w *fakeWeb) SetNotReady()                { w.notReady.Store(true) }
+
+func (w *fakeWeb) Shutdown(context.Context) error {
+	w.shutdown.Store(true)
+	return nil
+}
+
+func (w *fakeWeb) RegisterOnShutdown(srv *http.Server) {
+	srv.RegisterOnShutdown(func() { close(w.onShutdown) })
+}
+
+func newTestCombined(t *testing.T, cfg config.Server) (*CombinedServer, *fakeWeb) {
+	t.Helper()
+	gs := grpc.NewServer()
+	hs := healthz.New()
+	grpc_health_v1.RegisterHealthServer(gs, hs)
+	web := newFakeWeb()
+	s, err := NewCombinedServer(cfg, &Server{grpcServer: gs, healthServer: hs, operations: operations.NewService()}, web)
+	if err != nil {
+		t.Fatalf("NewCombinedServer() error = %v", err)
+	}
+	return s, web
+}
+
+func TestCombinedRouting(t *testing.T) {
+	s, web := newTestCombined(t, config.Server{GRPCWeb: config.GRPCWeb{Enabled: true, AllowedOrigins: []string{"*"}}})
+	ts := httptest.NewUnstartedServer(s)
+	ts.Config.Protocols = s.server.Protocols
+	ts.Start()
+	defer ts.Close()
+	defer s.grpc.grpcServer.Stop()
+
+	t.Run("gRPC over h2c", func(t *testing.T) {
+		conn, err := grpc.NewClient(ts.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			t.Fatalf("status = %s, want SERVING", resp.Status)
+		}
+		if web.served.Load() != 0 {
+			t.Fatal("gRPC call reached the web server")
+		}
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		wantWeb     bool
+	}{
+		{"plain HTTP", "", true},
+		{"JSON", "application/json", true},
+		// gRPC needs HTTP/2; over HTTP/1.1 it is just another request.
+		{"gRPC over HTTP/1.1", "application/grpc", true},
+		{"gRPC-Web", "application/grpc-web+proto", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := web.served.Load()
+			// An empty gRPC-Web frame: an empty HealthCheckRequest.
+			req, err := http.NewRequest(http.MethodPost, ts.URL+"/grpc.health.v1.Health/Check", bytes.NewReader(make([]byte, 5)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if got := web.served.Load() > before; got != tt.wantWeb {
+				t.Fatalf("served by web = %v, want %v", got, tt.wantWeb)
+			}
+			if !tt.wantWeb && resp.StatusCode != http.StatusOK {
+				t.Fatalf("gRPC-Web status code = %d, want 200", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCombinedDrainAndStop(t *testing.T) {
+	s, web := newTestCombined(t, config.Server{})
+
+	s.Drain()
+	resp, err := s.grpc.healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING || !web.notReady.Load() {
+		t.Fatalf("after Drain: gRPC health %s, web not ready %v; want both not serving", resp.Status, web.notReady.Load())
+	}
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if !web.shutdown.Load() {
+		t.Error("web server not shut down")
+	}
+	select {
+	case <-web.onShutdown:
+	case <-time.After(time.Second):
+		t.Fatal("web server's on-shutdown functions did not run")
+	}
+}
@@ -0,0 +1,318 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/braden0236/playground/internal/go-grpc/config"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebTrailerFlag marks the frame carrying the trailers at the end of
+	// a gRPC-Web response body.
+	grpcWebTrailerFlag = 0x80
+
+	grpcWebPreflightMaxAge = "600"
+)
+
+// grpcWeb translates gRPC-Web calls, binary (application/grpc-web) or text
+// (application/grpc-web-text), into gRPC calls served by the gRPC server's
+// ServeHTTP, including server streaming. See
+// https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md.
+//
+// Calls from browsers must come from an allowed origin; preflights are
+// answered for registered methods only.
+type grpcWeb struct {
+	server  *grpc.Server
+	origins []string
+	methods map[string]bool // "/package.Service/Method"
+}
+
+func newGRPCWeb(s *Server, cfg config.GRPCWeb) *grpcWeb {
+	g := &grpcWeb{
+		server:  s.grpcServer,
+		origins: cfg.AllowedOrigins,
+		methods: make(map[string]bool),
+	}
+	for name, info := range s.grpcServer.GetServiceInfo() {
+		for _, m := range info.Methods {
+			g.methods["/"+name+"/"+m.Name] = true
+		}
+	}
+	return g
+}
+
+// handles reports whether r is a gRPC-Web call or its preflight.
+func (g *grpcWeb) handles(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost:
+		return strings.HasPrefix(r.Header.Get("Content-Type"), grpcWebContentType)
+	case http.MethodOptions:
+		return r.Header.Get("Access-Control-Request-Method") == http.MethodPost && g.methods[r.URL.Path]
+	}
+	return false
+}
+
+func (g *grpcWeb) allowed(origin string) bool {
+	return slices.Contains(g.origins, "*") || slices.Contains(g.origins, origin)
+}
+
+func (g *grpcWeb) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		w.Header().Add("Vary", "Origin")
+		if !g.allowed(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if r.Method == http.MethodOptions {
+		h := w.Header()
+		h.Set("Access-Control-Allow-Methods", http.MethodPost)
+		if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+			h.Set("Access-Control-Allow-Headers", requested)
+		}
+		h.Set("Access-Control-Max-Age", grpcWebPreflightMaxAge)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// The subtype, e.g. +proto, carries over to the gRPC content type.
+	ct := r.Header.Get("Content-Type")
+	subtype, text := strings.CutPrefix(ct, grpcWebTextContentType)
+	if !text {
+		subtype = strings.TrimPrefix(ct, grpcWebContentType)
+	}
+
+	req := r.Clone(r.Context())
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2", 2, 0
+	req.Header.Set("Content-Type", "application/grpc"+subtype)
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+
+	resp := &grpcWebResponse{
+		w:           w,
+		header:      make(http.Header),
+		contentType: ct,
+		body:        w,
+	}
+	if text {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{base64.NewDecoder(base64.StdEncoding, r.Body), r.Body}
+		resp.enc = base64.NewEncoder(base64.StdEncoding, w)
+		resp.body = resp.enc
+	}
+
+	g.server.ServeHTTP(resp, req)
+	resp.finish()
+}
+
+// grpcWebResponse turns the gRPC server's HTTP/2 response into a gRPC-Web
+// one: the headers are sent as they are, and the trailers, which HTTP/1.1
+// can't carry, are appended to the body as a trailer frame.
+type grpcWebResponse struct {
+	w           http.ResponseWriter
+	header      http.Header // what the gRPC server sees as the response header
+	contentType string
+	wroteHeader bool
+
+	body io.Writer      // w, or enc in text mode
+	enc  io.WriteCloser // base64 encoder over w in text mode
+}
+
+func (r *grpcWebResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *grpcWebResponse) WriteHeader(code int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+
+	h := r.w.Header()
+	exposed := []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+	for k, vv := range r.header {
+		if k == "Trailer" || strings.HasPrefix(k, http.TrailerPrefix) || k == "Content-Type" {
+			continue
+		}
+		h[k] = vv
+		if !slices.Contains(exposed, k) {
+			exposed = append(exposed, k)
+		}
+	}
+	h.Set("Content-Type", r.contentType)
+	h.Set("Access-Control-Expose-Headers", strings.Join(exposed, ", "))
+	r.w.WriteHeader(code)
+}
+
+func (r *grpcWebResponse) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+// Flush sends what was written so far. In text mode the base64 encoding is
+// closed off first, so every flushed chunk decodes on its own.
+func (r *grpcWebResponse) Flush() {
+	r.WriteHeader(http.StatusOK)
+	if r.enc != nil {
+		_ = r.enc.Close()
+		r.enc = base64.NewEncoder(base64.StdEncoding, r.w)
+		r.body = r.enc
+	}
+	_ = http.NewResponseController(r.w).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection.
+func (r *grpcWebResponse) Unwrap() http.ResponseWriter {
+	return r.w
+}
+
+// trailer collects the trailers the gRPC server set: those it declared in
+// the Trailer header and those with http.TrailerPrefix.
+func (r *grpcWebResponse) trailer() http.Header {
+	t := make(http.Header)
+	for _, declared := range r.header.Values("Trailer") {
+		k := http.CanonicalHeaderKey(declared)
+		if vv := r.header[k]; len(vv) > 0 {
+			t[k] = vv
+		}
+	}
+	for k, vv := range r.header {
+		if name, ok := strings.CutPrefix(k, http.TrailerPrefix); ok {
+			k = http.CanonicalHeaderKey(name)
+			t[k] = append(t[k], vv...)
+		}
+	}
+	return t
+}
+
+func (r *grpcWebResponse) finish() {
+	r.WriteHeader(http.StatusOK)
+
+	t := r.trailer()
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var block bytes.Buffer
+	for _, k := range keys {
+		for _, v := range t[k] {
+			fmt.Fprintf(&block, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	if _, err := r.body.Write(append(frame, block.Bytes()...)); err != nil {
+		return
+	}
+	if r.enc != nil {
+		_ = r.enc.Close()
+	}
+	_ = http.NewResponseController(r.w).Flush()
+}
+
+// GRPCWebServer serves gRPC-Web over HTTP/1.1 next to the native gRPC
+// listener. It must stop before the gRPC server: GracefulStop panics on
+// calls still running through the gRPC server's ServeHTTP.
+type GRPCWebServer struct {
+	grpc     *Server
+	server   *http.Server
+	cfg      config.Server
+	inflight sync.WaitGroup
+}
+
+func NewGRPCWebServer(cfg config.Server, grpcSrv *Server) (*GRPCWebServer, error) {
+	web := newGRPCWeb(grpcSrv, cfg.GRPCWeb)
+
+	s := &GRPCWebServer{
+		grpc: grpcSrv,
+		cfg:  cfg,
+	}
+	s.server = &http.Server{
+		Addr: cfg.GRPCWeb.Address,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !web.handles(r) {
+				http.Error(w, "gRPC-Web requests only", http.StatusUnsupportedMediaType)
+				return
+			}
+			s.inflight.Add(1)
+			defer s.inflight.Done()
+			web.ServeHTTP(w, r)
+		}),
+	}
+
+	if cfg.UseTLS {
+		tlsConfig, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		s.server.TLSConfig = tlsConfig
+	}
+	return s, nil
+}
+
+func (s *GRPCWebServer) Run() error {
+	lis, err := net.Listen("tcp", s.cfg.GRPCWeb.Address)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("gRPC-Web server listening", "address", lis.Addr().String(), "tls", s.cfg.UseTLS)
+	if s.cfg.UseTLS {
+		err = s.server.ServeTLS(lis, "", "")
+	} else {
+		err = s.server.Serve(lis)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Stop ends health watches and running operations, waits for in-flight
+// calls until ctx is done, then closes the connections of calls still open
+// and waits for their handlers to return.
+func (s *GRPCWebServer) Stop(ctx context.Context) error {
+	slog.Info("shutting down gRPC-Web server gracefully")
+	s.grpc.prepareStop()
+
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		slog.Warn("closing open gRPC-Web calls", "error", err)
+		err = s.server.Close()
+	}
+	s.inflight.Wait()
+	return err
+}
+
+func (s *GRPCWebServer) RunFunc() (func() error, func(error)) {
+	return func() error {
+			return s.Run()
+		}, func(err error) {
+			ctx, cancel := context.WithTimeout(context.Background(), combinedStopTimeout)
+			defer cancel()
+			_ = s.Stop(ctx)
+		}
+}
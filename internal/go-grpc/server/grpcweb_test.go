@@ -0,0 +1,289 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/braden0236/playground/internal/go-grpc/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const checkMethod = "/grpc.health.v1.Health/Check"
+
+func newTestGRPCWeb(t *testing.T, origins ...string) *grpcWeb {
+	t.Helper()
+	gs := grpc.NewServer()
+	hs := health.NewServer()
+	hs.SetServingStatus("orders", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(gs, hs)
+	t.Cleanup(gs.Stop)
+	return newGRPCWeb(&Server{grpcServer: gs}, config.GRPCWeb{AllowedOrigins: origins})
+}
+
+func frame(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]byte, 5, 5+len(b))
+	binary.BigEndian.PutUint32(out[1:], uint32(len(b)))
+	return append(out, b...)
+}
+
+// readFrames splits a gRPC-Web response body into its messages and the
+// trailer block.
+func readFrames(t *testing.T, r io.Reader) (messages [][]byte, trailer string) {
+	t.Helper()
+	for {
+		var hdr [5]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			t.Fatalf("reading frame header: %v", err)
+		}
+		data := make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			t.Fatalf("reading frame: %v", err)
+		}
+		if hdr[0]&grpcWebTrailerFlag != 0 {
+			return messages, string(data)
+		}
+		messages = append(messages, data)
+	}
+}
+
+func checkStatus(t *testing.T, data []byte, want grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	var resp grpc_health_v1.HealthCheckResponse
+	if err := proto.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != want {
+		t.Fatalf("status = %s, want %s", resp.Status, want)
+	}
+}
+
+func TestGRPCWebUnary(t *testing.T) {
+	g := newTestGRPCWeb(t, "https://app.test")
+
+	tests := []struct {
+		name        string
+		contentType string
+		service     string
+		wantMessage bool
+		wantTrailer string
+	}{
+		{name: "binary", contentType: "application/grpc-web+proto", service: "orders", wantMessage: true, wantTrailer: "grpc-status: 0\r\n"},
+		{name: "binary without subtype", contentType: "application/grpc-web", service: "orders", wantMessage: true, wantTrailer: "grpc-status: 0\r\n"},
+		{name: "text", contentType: "application/grpc-web-text", service: "orders", wantMessage: true, wantTrailer: "grpc-status: 0\r\n"},
+		{name: "error status", contentType: "application/grpc-web+proto", service: "billing", wantTrailer: "grpc-message: unknown service\r\ngrpc-status: 5\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := strings.HasPrefix(tt.contentType, grpcWebTextContentType)
+			body := frame(t, &grpc_health_v1.HealthCheckRequest{Service: tt.service})
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+			req := httptest.NewRequest(http.MethodPost, checkMethod, bytes.NewReader(body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Origin", "https://app.test")
+			if !g.handles(req) {
+				t.Fatal("handles() = false")
+			}
+
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %q", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Fatalf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.test" {
+				t.Fatalf("Access-Control-Allow-Origin = %q", got)
+			}
+			if got := w.Header().Get("Access-Control-Expose-Headers"); !strings.Contains(got, "Grpc-Status") {
+				t.Fatalf("Access-Control-Expose-Headers = %q, want it to contain Grpc-Status", got)
+			}
+			if w.Header().Get("Grpc-Status") != "" || w.Header().Get("Trailer") != "" {
+				t.Fatalf("trailers sent as headers: %v", w.Header())
+			}
+
+			var r io.Reader = w.Body
+			if text {
+				r = base64.NewDecoder(base64.StdEncoding, w.Body)
+			}
+			messages, trailer := readFrames(t, r)
+			if trailer != tt.wantTrailer {
+				t.Fatalf("trailer = %q, want %q", trailer, tt.wantTrailer)
+			}
+			if !tt.wantMessage {
+				if len(messages) != 0 {
+					t.Fatalf("got %d messages, want none", len(messages))
+				}
+				return
+			}
+			if len(messages) != 1 {
+				t.Fatalf("got %d messages, want 1", len(messages))
+			}
+			checkStatus(t, messages[0], grpc_health_v1.HealthCheckResponse_SERVING)
+		})
+	}
+}
+
+// TestGRPCWebStreaming checks that streamed messages reach the client as
+// they are sent rather than when the call ends.
+func TestGRPCWebStreaming(t *testing.T) {
+	g := newTestGRPCWeb(t, "*")
+	srv := httptest.NewServer(g)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := frame(t, &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/grpc.health.v1.Health/Watch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var hdr [5]byte
+	if _, err := io.ReadFull(resp.Body, hdr[:]); err != nil {
+		t.Fatalf("reading the first streamed frame: %v", err)
+	}
+	data := make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		t.Fatal(err)
+	}
+	checkStatus(t, data, grpc_health_v1.HealthCheckResponse_SERVING)
+}
+
+// TestGRPCWebTextFlush checks that in text mode every flushed chunk is
+// complete base64, as clients decode chunks as they arrive.
+func TestGRPCWebTextFlush(t *testing.T) {
+	w := httptest.NewRecorder()
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	resp := &grpcWebResponse{w: w, header: make(http.Header), contentType: grpcWebTextContentType, body: enc, enc: enc}
+
+	_, _ = resp.Write([]byte("abcd"))
+	resp.Flush()
+	first := w.Body.String()
+	if got, err := base64.StdEncoding.DecodeString(first); err != nil || string(got) != "abcd" {
+		t.Fatalf("first chunk %q decodes to %q, %v", first, got, err)
+	}
+
+	resp.header.Add("Trailer", "Grpc-Status")
+	resp.header.Set("Grpc-Status", "0")
+	resp.finish()
+	rest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(w.Body.String(), first))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\x80\x00\x00\x00\x10grpc-status: 0\r\n"; string(rest) != want {
+		t.Fatalf("trailer frame = %q, want %q", rest, want)
+	}
+}
+
+func TestGRPCWebCORS(t *testing.T) {
+	g := newTestGRPCWeb(t, "https://app.test")
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		headers     map[string]string
+		wantHandled bool
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			path:   checkMethod,
+			headers: map[string]string{
+				"Origin":                         "https://app.test",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "content-type,x-grpc-web,x-user-agent",
+			},
+			wantHandled: true,
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.test",
+				"Access-Control-Allow-Methods": "POST",
+				"Access-Control-Allow-Headers": "content-type,x-grpc-web,x-user-agent",
+			},
+		},
+		{
+			name:        "preflight from another origin",
+			method:      http.MethodOptions,
+			path:        checkMethod,
+			headers:     map[string]string{"Origin": "https://evil.test", "Access-Control-Request-Method": "POST"},
+			wantHandled: true,
+			wantStatus:  http.StatusForbidden,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:    "preflight for an unknown method",
+			method:  http.MethodOptions,
+			path:    "/grpc.health.v1.Health/Delete",
+			headers: map[string]string{"Origin": "https://app.test", "Access-Control-Request-Method": "POST"},
+		},
+		{
+			name:        "call from another origin",
+			method:      http.MethodPost,
+			path:        checkMethod,
+			headers:     map[string]string{"Origin": "https://evil.test", "Content-Type": "application/grpc-web"},
+			wantHandled: true,
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:    "native gRPC",
+			method:  http.MethodPost,
+			path:    checkMethod,
+			headers: map[string]string{"Content-Type": "application/grpc"},
+		},
+		{
+			name:   "GET",
+			method: http.MethodGet,
+			path:   checkMethod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := g.handles(req); got != tt.wantHandled {
+				t.Fatalf("handles() = %v, want %v", got, tt.wantHandled)
+			}
+			if !tt.wantHandled {
+				return
+			}
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for k, want := range tt.wantHeaders {
+				if got := w.Header().Get(k); got != want {
+					t.Fatalf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/braden0236/playground/pkg/admin"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maintenanceExempt lists services that keep answering in maintenance mode
+// so probes and tooling still work.
+var maintenanceExempt = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+func inMaintenance(m *admin.Maintenance, method string) bool {
+	if !m.Enabled() {
+		return false
+	}
+	for _, prefix := range maintenanceExempt {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func maintenanceUnaryInterceptor(m *admin.Maintenance) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if inMaintenance(m, info.FullMethod) {
+			return nil, status.Error(codes.Unavailable, "service under maintenance")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func maintenanceStreamInterceptor(m *admin.Maintenance) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if inMaintenance(m, info.FullMethod) {
+			return status.Error(codes.Unavailable, "service under maintenance")
+		}
+		return handler(srv, ss)
+	}
+}
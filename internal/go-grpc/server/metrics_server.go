@@ -2,7 +2,7 @@ package server
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"sync"
 
@@ -19,33 +19,34 @@ type MetricsServer struct {
 
 func NewMetricsServer(cfg config.Server) *MetricsServer {
 	mux := http.NewServeMux()
-	mux.Handle(cfg.Metrics.Path, promhttp.Handler())
+	// Only the metrics path; admin routes check their own credentials.
+	mux.Handle(cfg.Metrics.Path, wrapMetricsHandler(cfg, promhttp.Handler()))
 
 	s := &MetricsServer{
 		mux: mux,
 		cfg: cfg,
 		server: &http.Server{
 			Addr:    cfg.Metrics.Address,
-			Handler: wrapMetricsHandler(cfg, mux),
+			Handler: mux,
 		},
 	}
 	return s
 }
 
 func (s *MetricsServer) Register(pattern string, handler http.Handler) {
-	log.Printf("Registering metrics handler for %s", pattern)
+	slog.Debug("registering metrics handler", "pattern", pattern)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.mux.Handle(pattern, handler)
 }
 
 func (s *MetricsServer) Run() error {
-	log.Printf("Metrics server listening at %s", s.cfg.Metrics.Address)
+	slog.Info("metrics server listening", "address", s.cfg.Metrics.Address)
 	return s.server.ListenAndServe()
 }
 
 func (s *MetricsServer) Stop(ctx context.Context) error {
-	log.Println("Shutting down metrics server gracefully")
+	slog.Info("shutting down metrics server gracefully")
 	return s.server.Shutdown(ctx)
 }
 
@@ -54,7 +55,7 @@ func (s *MetricsServer) RunFunc() (func() error, func(error)) {
 			return s.Run()
 		}, func(err error) {
 			if err != nil {
-				log.Printf("MetricsServer interrupted due to error: %v", err)
+				slog.Error("metrics server interrupted", "error", err)
 			}
 			_ = s.Stop(context.Background())
 		}
@@ -0,0 +1,340 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	namePrefix      = "operations/"
+	defaultPageSize = 50
+
+	DefaultRetention = time.Hour
+)
+
+// Work is the body of a long-running operation. It reports how many items it
+// has processed through progress and should return promptly once ctx is
+// cancelled.
+type Work func(ctx context.Context, progress func(processed int)) (proto.Message, error)
+
+// Service implements google.longrunning.Operations over operations started
+// in this process. State is kept in memory only, and finished operations are
+// forgotten once the retention has passed.
+type Service struct {
+	longrunningpb.UnimplementedOperationsServer
+
+	retention time.Duration
+	now       func() time.Time
+
+	mu  sync.RWMutex
+	seq int
+	ops map[string]*operation
+}
+
+type operation struct {
+	seq      int
+	op       *longrunningpb.Operation
+	metadata *orderpb.BulkOperationMetadata
+	cancel   context.CancelFunc
+	done     chan struct{}
+	finished time.Time
+}
+
+type Option func(*Service)
+
+// WithRetention sets how long finished operations can still be read.
+func WithRetention(d time.Duration) Option {
+	return func(s *Service) {
+		if d > 0 {
+			s.retention = d
+		}
+	}
+}
+
+func NewService(opts ...Option) *Service {
+	s := &Service{
+		retention: DefaultRetention,
+		now:       time.Now,
+		ops:       make(map[string]*operation),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start runs work in the background and returns the pending Operation.
+func (s *Service) Start(kind string, total int, work Work) (*longrunningpb.Operation, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	s.sweep(s.now())
+	s.seq++
+	name := namePrefix + strconv.Itoa(s.seq)
+	o := &operation{
+		seq: s.seq,
+		op:  &longrunningpb.Operation{Name: name},
+		metadata: &orderpb.BulkOperationMetadata{
+			Kind:      kind,
+			Total:     int32(total),
+			StartTime: timestamppb.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if err := o.syncMetadata(); err != nil {
+		s.mu.Unlock()
+		cancel()
+		return nil, status.Errorf(codes.Internal, "marshal metadata: %v", err)
+	}
+	s.ops[name] = o
+	snapshot := proto.Clone(o.op).(*longrunningpb.Operation)
+	s.mu.Unlock()
+
+	slog.Info("operation started", "operation", name, "kind", kind, "total", total)
+
+	go s.run(ctx, o, work)
+
+	return snapshot, nil
+}
+
+func (s *Service) run(ctx context.Context, o *operation, work Work) {
+	defer o.cancel()
+	defer close(o.done)
+
+	resp, err := work(ctx, func(processed int) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		o.metadata.Processed = int32(processed)
+		_ = o.syncMetadata()
+	})
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o.finished = s.now()
+	o.metadata.EndTime = timestamppb.New(o.finished)
+	_ = o.syncMetadata()
+	o.op.Done = true
+
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			st = status.FromContextError(err)
+		}
+		o.op.Result = &longrunningpb.Operation_Error{Error: st.Proto()}
+		slog.Warn("operation failed", "operation", o.op.Name, "error", err)
+		return
+	}
+
+	a, err := anypb.New(resp)
+	if err != nil {
+		o.op.Result = &longrunningpb.Operation_Error{Error: &spb.Status{
+			Code:    int32(codes.Internal),
+			Message: fmt.Sprintf("marshal response: %v", err),
+		}}
+		return
+	}
+	o.op.Result = &longrunningpb.Operation_Response{Response: a}
+	slog.Info("operation completed", "operation", o.op.Name)
+}
+
+// syncMetadata copies the typed metadata into the Operation. Callers must
+// hold s.mu.
+func (o *operation) syncMetadata() error {
+	a, err := anypb.New(o.metadata)
+	if err != nil {
+		return err
+	}
+	o.op.Metadata = a
+	return nil
+}
+
+// expired reports whether o finished longer than the retention ago. Callers
+// must hold s.mu.
+func (s *Service) expired(o *operation, now time.Time) bool {
+	return o.op.Done && now.Sub(o.finished) > s.retention
+}
+
+// sweep drops expired operations. Callers must hold s.mu for writing.
+func (s *Service) sweep(now time.Time) {
+	for name, o := range s.ops {
+		if s.expired(o, now) {
+			delete(s.ops, name)
+		}
+	}
+}
+
+// lookup treats expired operations as gone even before they are swept.
+func (s *Service) lookup(name string) (*operation, error) {
+	o, ok := s.ops[name]
+	if !ok || s.expired(o, s.now()) {
+		return nil, status.Errorf(codes.NotFound, "operation %s not found", name)
+	}
+	return o, nil
+}
+
+func (s *Service) GetOperation(ctx context.Context, req *longrunningpb.GetOperationRequest) (*longrunningpb.Operation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	o, err := s.lookup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(o.op).(*longrunningpb.Operation), nil
+}
+
+// ListOperations supports the filters "done=true" and "done=false"; the
+// page token is the offset into the list ordered by start.
+func (s *Service) ListOperations(ctx context.Context, req *longrunningpb.ListOperationsRequest) (*longrunningpb.ListOperationsResponse, error) {
+	var wantDone *bool
+	switch f := strings.ReplaceAll(req.Filter, " ", ""); f {
+	case "":
+	case "done=true", "done=false":
+		v := f == "done=true"
+		wantDone = &v
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported filter %q", req.Filter)
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		n, err := strconv.Atoi(req.PageToken)
+		if err != nil || n < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %q", req.PageToken)
+		}
+		offset = n
+	}
+	size := int(req.PageSize)
+	if size < 1 {
+		size = defaultPageSize
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	matched := make([]*operation, 0, len(s.ops))
+	for _, o := range s.ops {
+		if s.expired(o, now) {
+			continue
+		}
+		if wantDone == nil || o.op.Done == *wantDone {
+			matched = append(matched, o)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].seq < matched[j].seq
+	})
+
+	resp := &longrunningpb.ListOperationsResponse{}
+	for i := offset; i < len(matched) && i < offset+size; i++ {
+		resp.Operations = append(resp.Operations, proto.Clone(matched[i].op).(*longrunningpb.Operation))
+	}
+	if offset+size < len(matched) {
+		resp.NextPageToken = strconv.Itoa(offset + size)
+	}
+	return resp, nil
+}
+
+// DeleteOperation forgets a finished operation. Running operations must be
+// cancelled first.
+func (s *Service) DeleteOperation(ctx context.Context, req *longrunningpb.DeleteOperationRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.lookup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !o.op.Done {
+		return nil, status.Errorf(codes.FailedPrecondition, "operation %s is still running", req.Name)
+	}
+	delete(s.ops, req.Name)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Service) CancelOperation(ctx context.Context, req *longrunningpb.CancelOperationRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.lookup(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !o.op.Done {
+		slog.Info("operation cancel requested", "operation", req.Name)
+		o.metadata.CancelRequested = true
+		_ = o.syncMetadata()
+		o.cancel()
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WaitOperation blocks until the operation is done, the request timeout
+// elapses or the caller's deadline expires, and returns the latest state.
+func (s *Service) WaitOperation(ctx context.Context, req *longrunningpb.WaitOperationRequest) (*longrunningpb.Operation, error) {
+	s.mu.RLock()
+	o, err := s.lookup(req.Name)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout.AsDuration())
+		defer cancel()
+	}
+
+	select {
+	case <-o.done:
+	case <-ctx.Done():
+	}
+
+	return s.GetOperation(context.WithoutCancel(ctx), &longrunningpb.GetOperationRequest{Name: req.Name})
+}
+
+// CancelAll cancels every running operation and waits up to timeout for
+// them to finish.
+func (s *Service) CancelAll(timeout time.Duration) {
+	s.mu.RLock()
+	var running []*operation
+	for _, o := range s.ops {
+		if !o.op.Done {
+			o.cancel()
+			running = append(running, o)
+		}
+	}
+	s.mu.RUnlock()
+
+	deadline := time.After(timeout)
+	for _, o := range running {
+		select {
+		case <-o.done:
+		case <-deadline:
+			return
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestService(opts ...Option) (*Service, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewService(opts...)
+	s.now = clock.Now
+	return s, clock
+}
+
+// blockingWork reports one item of progress and then waits for release or
+// cancellation.
+func blockingWork(release <-chan struct{}, result proto.Message, err error) Work {
+	return func(ctx context.Context, progress func(int)) (proto.Message, error) {
+		progress(1)
+		select {
+		case <-release:
+			return result, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func wait(t *testing.T, s *Service, name string) *longrunningpb.Operation {
+	t.Helper()
+	op, err := s.WaitOperation(context.Background(), &longrunningpb.WaitOperationRequest{
+		Name:    name,
+		Timeout: durationpb.New(5 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("WaitOperation() error = %v", err)
+	}
+	if !op.Done {
+		t.Fatalf("operation %s not done after WaitOperation", name)
+	}
+	return op
+}
+
+func TestOperationResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   proto.Message
+		err      error
+		wantCode codes.Code
+	}{
+		{"response", &orderpb.BulkUpdateOrderStatusResponse{Updated: 2}, nil, codes.OK},
+		{"status error", nil, status.Error(codes.FailedPrecondition, "nope"), codes.FailedPrecondition},
+		{"plain error", nil, errors.New("boom"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService()
+			release := make(chan struct{})
+			op, err := s.Start("Test", 3, blockingWork(release, tt.result, tt.err))
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			if op.Done || op.Name != "operations/1" {
+				t.Fatalf("Start() = %v, want pending operations/1", op)
+			}
+
+			close(release)
+			op = wait(t, s, op.Name)
+
+			md := &orderpb.BulkOperationMetadata{}
+			if err := op.Metadata.UnmarshalTo(md); err != nil {
+				t.Fatalf("unmarshal metadata: %v", err)
+			}
+			if md.Kind != "Test" || md.Total != 3 || md.Processed != 1 || md.EndTime == nil {
+				t.Errorf("metadata = %v", md)
+			}
+
+			if tt.wantCode == codes.OK {
+				got := &orderpb.BulkUpdateOrderStatusResponse{}
+				if op.GetResponse() == nil || op.GetResponse().UnmarshalTo(got) != nil || !proto.Equal(got, tt.result) {
+					t.Fatalf("response = %v, want %v", op.GetResponse(), tt.result)
+				}
+				return
+			}
+			if got := codes.Code(op.GetError().GetCode()); got != tt.wantCode {
+				t.Fatalf("error code = %s, want %s", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCancelOperation(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+	op, err := s.Start("Test", 1, blockingWork(make(chan struct{}), nil, nil))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if _, err := s.DeleteOperation(ctx, &longrunningpb.DeleteOperationRequest{Name: op.Name}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("DeleteOperation() of a running operation error = %v, want FailedPrecondition", err)
+	}
+	if _, err := s.CancelOperation(ctx, &longrunningpb.CancelOperationRequest{Name: op.Name}); err != nil {
+		t.Fatalf("CancelOperation() error = %v", err)
+	}
+	op = wait(t, s, op.Name)
+	if got := codes.Code(op.GetError().GetCode()); got != codes.Canceled {
+		t.Fatalf("error code = %s, want Canceled", got)
+	}
+	md := &orderpb.BulkOperationMetadata{}
+	_ = op.Metadata.UnmarshalTo(md)
+	if !md.CancelRequested {
+		t.Error("metadata cancel_requested = false")
+	}
+
+	// Cancelling a finished operation is a no-op.
+	if _, err := s.CancelOperation(ctx, &longrunningpb.CancelOperationRequest{Name: op.Name}); err != nil {
+		t.Fatalf("second CancelOperation() error = %v", err)
+	}
+	if _, err := s.DeleteOperation(ctx, &longrunningpb.DeleteOperationRequest{Name: op.Name}); err != nil {
+		t.Fatalf("DeleteOperation() error = %v", err)
+	}
+	if _, err := s.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: op.Name}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetOperation() after delete error = %v, want NotFound", err)
+	}
+}
+
+func TestListOperations(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	var names []string
+	for i := range 3 {
+		var r <-chan struct{} = make(chan struct{})
+		if i < 2 {
+			r = release
+		}
+		op, err := s.Start("Test", 1, blockingWork(r, &orderpb.ExportOrdersResponse{}, nil))
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		names = append(names, op.Name)
+	}
+	close(release)
+	wait(t, s, names[0])
+	wait(t, s, names[1])
+	defer s.CancelAll(time.Second)
+
+	tests := []struct {
+		name     string
+		req      *longrunningpb.ListOperationsRequest
+		want     []string
+		wantNext string
+		wantCode codes.Code
+	}{
+		{"all", &longrunningpb.ListOperationsRequest{}, names, "", codes.OK},
+		{"done", &longrunningpb.ListOperationsRequest{Filter: "done = true"}, names[:2], "", codes.OK},
+		{"running", &longrunningpb.ListOperationsRequest{Filter: "done=false"}, names[2:], "", codes.OK},
+		{"first page", &longrunningpb.ListOperationsRequest{PageSize: 2}, names[:2], "2", codes.OK},
+		{"second page", &longrunningpb.ListOperationsRequest{PageSize: 2, PageToken: "2"}, names[2:], "", codes.OK},
+		{"unsupported filter", &longrunningpb.ListOperationsRequest{Filter: "kind=Test"}, nil, "", codes.InvalidArgument},
+		{"bad page token", &longrunningpb.ListOperationsRequest{PageToken: "x"}, nil, "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListOperations(ctx, tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("ListOperations() code = %s, want %s (error %v)", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			var got []string
+			for _, op := range resp.Operations {
+				got = append(got, op.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListOperations() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ListOperations() = %q, want %q", got, tt.want)
+				}
+			}
+			if resp.NextPageToken != tt.wantNext {
+				t.Errorf("next_page_token = %q, want %q", resp.NextPageToken, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	s, clock := newTestService(WithRetention(time.Minute))
+	ctx := context.Background()
+
+	done, err := s.Start("Test", 0, blockingWork(nil, &orderpb.ExportOrdersResponse{}, nil))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if _, err := s.CancelOperation(ctx, &longrunningpb.CancelOperationRequest{Name: done.Name}); err != nil {
+		t.Fatalf("CancelOperation() error = %v", err)
+	}
+	wait(t, s, done.Name)
+	running, err := s.Start("Test", 1, blockingWork(make(chan struct{}), nil, nil))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer s.CancelAll(time.Second)
+
+	clock.Add(time.Minute)
+	if _, err := s.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: done.Name}); err != nil {
+		t.Fatalf("GetOperation() within the retention error = %v", err)
+	}
+
+	clock.Add(time.Second)
+	if _, err := s.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: done.Name}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetOperation() after the retention error = %v, want NotFound", err)
+	}
+	if _, err := s.GetOperation(ctx, &longrunningpb.GetOperationRequest{Name: running.Name}); err != nil {
+		t.Fatalf("GetOperation() of a running operation error = %v, running ones never expire", err)
+	}
+	resp, err := s.ListOperations(ctx, &longrunningpb.ListOperationsRequest{})
+	if err != nil || len(resp.Operations) != 1 || resp.Operations[0].Name != running.Name {
+		t.Fatalf("ListOperations() = %v, %v, want only %s", resp, err, running.Name)
+	}
+
+	// Starting an operation sweeps expired ones from memory.
+	if _, err := s.Start("Test", 1, blockingWork(make(chan struct{}), nil, nil)); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	s.mu.RLock()
+	_, kept := s.ops[done.Name]
+	s.mu.RUnlock()
+	if kept {
+		t.Fatal("expired operation still stored after Start")
+	}
+}
+
+func TestWaitOperationTimeout(t *testing.T) {
+	s, _ := newTestService()
+	op, err := s.Start("Test", 1, blockingWork(make(chan struct{}), nil, nil))
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer s.CancelAll(time.Second)
+
+	op, err = s.WaitOperation(context.Background(), &longrunningpb.WaitOperationRequest{
+		Name:    op.Name,
+		Timeout: durationpb.New(10 * time.Millisecond),
+	})
+	if err != nil {
+		t.Fatalf("WaitOperation() error = %v", err)
+	}
+	if op.Done {
+		t.Fatal("WaitOperation() returned a done operation for still running work")
+	}
+
+	if _, err := s.WaitOperation(context.Background(), &longrunningpb.WaitOperationRequest{Name: "operations/99"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("WaitOperation() of an unknown operation error = %v, want NotFound", err)
+	}
+}
+
+func TestCancelAll(t *testing.T) {
+	s, _ := newTestService()
+	var ops []*longrunningpb.Operation
+	for range 2 {
+		op, err := s.Start("Test", 1, blockingWork(make(chan struct{}), nil, nil))
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		ops = append(ops, op)
+	}
+
+	s.CancelAll(5 * time.Second)
+
+	for _, op := range ops {
+		got, err := s.GetOperation(context.Background(), &longrunningpb.GetOperationRequest{Name: op.Name})
+		if err != nil {
+			t.Fatalf("GetOperation() error = %v", err)
+		}
+		if !got.Done {
+			t.Errorf("%s not done after CancelAll", op.Name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package order
+
+import (
+	"context"
+	"sort"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	kindExportOrders          = "ExportOrders"
+	kindBulkUpdateOrderStatus = "BulkUpdateOrderStatus"
+)
+
+func (s *Service) ExportOrders(ctx context.Context, req *orderpb.ExportOrdersRequest) (*longrunningpb.Operation, error) {
+	logging.FromContext(ctx).Info("export orders", "status", req.Status)
+
+	if s.operations == nil {
+		return nil, status.Error(codes.Unimplemented, "long-running operations are not enabled")
+	}
+
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.orders))
+	for id, o := range s.orders {
+		if req.Status == "" || o.Status == req.Status {
+			ids = append(ids, id)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Strings(ids)
+
+	return s.operations.Start(kindExportOrders, len(ids), func(ctx context.Context, progress func(int)) (proto.Message, error) {
+		resp := &orderpb.ExportOrdersResponse{}
+		for i, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			s.mu.RLock()
+			if o, ok := s.orders[id]; ok {
+				resp.Orders = append(resp.Orders, proto.Clone(o).(*orderpb.OrderResponse))
+			}
+			s.mu.RUnlock()
+
+			progress(i + 1)
+		}
+		return resp, nil
+	})
+}
+
+func (s *Service) BulkUpdateOrderStatus(ctx context.Context, req *orderpb.BulkUpdateOrderStatusRequest) (*longrunningpb.Operation, error) {
+	logging.FromContext(ctx).Info("bulk update order status", "orders", len(req.OrderIds), "status", req.Status)
+
+	if s.operations == nil {
+		return nil, status.Error(codes.Unimplemented, "long-running operations are not enabled")
+	}
+	if req.Status == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+	if len(req.OrderIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_ids must not be empty")
+	}
+
+	ids := append([]string(nil), req.OrderIds...)
+	newStatus := req.Status
+
+	return s.operations.Start(kindBulkUpdateOrderStatus, len(ids), func(ctx context.Context, progress func(int)) (proto.Message, error) {
+		resp := &orderpb.BulkUpdateOrderStatusResponse{}
+		for i, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			s.mu.Lock()
+			if o, ok := s.orders[id]; ok {
+				o.Status = newStatus
+				resp.Updated++
+			} else {
+				resp.FailedOrderIds = append(resp.FailedOrderIds, id)
+			}
+			s.mu.Unlock()
+
+			progress(i + 1)
+		}
+		return resp, nil
+	})
+}
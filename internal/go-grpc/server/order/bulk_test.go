@@ -0,0 +1,121 @@
+package order
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/braden0236/playground/internal/go-grpc/server/operations"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestBulkWithoutOperations(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	if _, err := s.ExportOrders(ctx, &orderpb.ExportOrdersRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ExportOrders() error = %v, want Unimplemented", err)
+	}
+	if _, err := s.BulkUpdateOrderStatus(ctx, &orderpb.BulkUpdateOrderStatusRequest{OrderIds: []string{"o1"}, Status: "X"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("BulkUpdateOrderStatus() error = %v, want Unimplemented", err)
+	}
+}
+
+func TestExportOrders(t *testing.T) {
+	ops := operations.NewService()
+	s := newTestService(t, WithOperations(ops))
+	ctx := context.Background()
+	for _, id := range []string{"o3", "o2"} {
+		if _, err := s.CreateOrder(ctx, &orderpb.CreateOrderRequest{OrderId: id}); err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+	}
+	if _, err := s.UpdateOrder(ctx, &orderpb.UpdateOrderRequest{OrderId: "o2", Status: StatusShipped}); err != nil {
+		t.Fatalf("UpdateOrder() error = %v", err)
+	}
+
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{"", []string{"o1", "o2", "o3"}},
+		{StatusCreated, []string{"o1", "o3"}},
+		{StatusDelivered, nil},
+	}
+	for _, tt := range tests {
+		op, err := s.ExportOrders(ctx, &orderpb.ExportOrdersRequest{Status: tt.status})
+		if err != nil {
+			t.Fatalf("ExportOrders(%q) error = %v", tt.status, err)
+		}
+		resp := &orderpb.ExportOrdersResponse{}
+		waitResponse(t, ops, op.Name, resp)
+
+		var ids []string
+		for _, o := range resp.Orders {
+			ids = append(ids, o.OrderId)
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("ExportOrders(%q) = %q, want %q", tt.status, ids, tt.want)
+		}
+	}
+}
+
+func TestBulkUpdateOrderStatus(t *testing.T) {
+	ops := operations.NewService()
+	s := newTestService(t, WithOperations(ops))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  *orderpb.BulkUpdateOrderStatusRequest
+		want codes.Code
+	}{
+		{"missing status", &orderpb.BulkUpdateOrderStatusRequest{OrderIds: []string{"o1"}}, codes.InvalidArgument},
+		{"no orders", &orderpb.BulkUpdateOrderStatusRequest{Status: StatusShipped}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if _, err := s.BulkUpdateOrderStatus(ctx, tt.req); status.Code(err) != tt.want {
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+
+	op, err := s.BulkUpdateOrderStatus(ctx, &orderpb.BulkUpdateOrderStatusRequest{OrderIds: []string{"o1", "missing"}, Status: StatusShipped})
+	if err != nil {
+		t.Fatalf("BulkUpdateOrderStatus() error = %v", err)
+	}
+	resp := &orderpb.BulkUpdateOrderStatusResponse{}
+	waitResponse(t, ops, op.Name, resp)
+
+	want := &orderpb.BulkUpdateOrderStatusResponse{Updated: 1, FailedOrderIds: []string{"missing"}}
+	if !proto.Equal(resp, want) {
+		t.Fatalf("response = %v, want %v", resp, want)
+	}
+	o, _ := s.GetOrder(ctx, &orderpb.OrderRequest{OrderId: "o1"})
+	if o.Status != StatusShipped {
+		t.Fatalf("order status = %s, want %s", o.Status, StatusShipped)
+	}
+}
+
+func waitResponse(t *testing.T, ops *operations.Service, name string, resp proto.Message) {
+	t.Helper()
+	op, err := ops.WaitOperation(context.Background(), &longrunningpb.WaitOperationRequest{
+		Name:    name,
+		Timeout: durationpb.New(5 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("WaitOperation() error = %v", err)
+	}
+	if op.GetResponse() == nil {
+		t.Fatalf("operation %s = %v, want a response", name, op)
+	}
+	if err := op.GetResponse().UnmarshalTo(resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package order
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	customerNameWeight = 2
+	descriptionWeight  = 1
+	orderIDWeight      = 1
+
+	// prefixMatchFactor discounts terms that only start with a query token.
+	prefixMatchFactor = 0.5
+)
+
+// index is an inverted index over the searchable fields of orders. It is not
+// safe for concurrent use; the Service guards it with its own mutex.
+type index struct {
+	postings map[string]map[string]int // term -> order ID -> weighted frequency
+	docs     map[string]map[string]int // order ID -> term -> weighted frequency
+	terms    []string                  // sorted keys of postings, for prefix lookups
+}
+
+type hit struct {
+	id    string
+	score float64
+}
+
+func newIndex() *index {
+	return &index{
+		postings: make(map[string]map[string]int),
+		docs:     make(map[string]map[string]int),
+	}
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func (ix *index) put(id, customerName, description string) {
+	ix.remove(id)
+
+	freqs := make(map[string]int)
+	for _, t := range tokenize(customerName) {
+		freqs[t] += customerNameWeight
+	}
+	for _, t := range tokenize(description) {
+		freqs[t] += descriptionWeight
+	}
+	for _, t := range tokenize(id) {
+		freqs[t] += orderIDWeight
+	}
+
+	for t, f := range freqs {
+		p, ok := ix.postings[t]
+		if !ok {
+			p = make(map[string]int)
+			ix.postings[t] = p
+			i := sort.SearchStrings(ix.terms, t)
+			ix.terms = append(ix.terms, "")
+			copy(ix.terms[i+1:], ix.terms[i:])
+			ix.terms[i] = t
+		}
+		p[id] = f
+	}
+	ix.docs[id] = freqs
+}
+
+func (ix *index) remove(id string) {
+	freqs, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	delete(ix.docs, id)
+
+	for t := range freqs {
+		p := ix.postings[t]
+		delete(p, id)
+		if len(p) > 0 {
+			continue
+		}
+		delete(ix.postings, t)
+		if i := sort.SearchStrings(ix.terms, t); i < len(ix.terms) && ix.terms[i] == t {
+			ix.terms = append(ix.terms[:i], ix.terms[i+1:]...)
+		}
+	}
+}
+
+// search returns the orders matching every token of query, either exactly or
+// by prefix, ranked by a tf-idf score with ties broken by order ID.
+func (ix *index) search(query string) []hit {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	n := float64(len(ix.docs))
+	var scores map[string]float64
+	for _, tok := range tokens {
+		matched := make(map[string]float64)
+		for i := sort.SearchStrings(ix.terms, tok); i < len(ix.terms) && strings.HasPrefix(ix.terms[i], tok); i++ {
+			term := ix.terms[i]
+			p := ix.postings[term]
+			idf := math.Log(1 + n/float64(len(p)))
+			factor := 1.0
+			if term != tok {
+				factor = prefixMatchFactor
+			}
+			for id, f := range p {
+				matched[id] += factor * float64(f) * idf
+			}
+		}
+
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for id := range scores {
+			if m, ok := matched[id]; ok {
+				scores[id] += m
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := make([]hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, hit{id: id, score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].id < hits[j].id
+	})
+	return hits
+}
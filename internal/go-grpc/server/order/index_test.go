@@ -0,0 +1,154 @@
+package order
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"Alice Smith", []string{"alice", "smith"}},
+		{"order-001, rush!", []string{"order", "001", "rush"}},
+		{"  Ünïcode  names ", []string{"ünïcode", "names"}},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	ix := newIndex()
+	ix.put("o1", "Alice Smith", "red bicycle")
+	ix.put("o2", "Bob Jones", "alice's red scooter")
+	ix.put("o3", "Carol", "blue bicycle bell")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"customer name outranks description", "alice", []string{"o1", "o2"}},
+		{"every token must match", "red bicycle", []string{"o1"}},
+		{"prefix match", "bicy", []string{"o1", "o3"}},
+		{"exact match outranks prefix", "bell", []string{"o3"}},
+		{"order ID", "o2", []string{"o2"}},
+		{"case insensitive", "CAROL", []string{"o3"}},
+		{"no match", "truck", []string{}},
+		{"no tokens", "!!", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := ix.search(tt.query)
+			if tt.want == nil {
+				if hits != nil {
+					t.Fatalf("search(%q) = %v, want nil", tt.query, hits)
+				}
+				return
+			}
+			if got := hitIDs(hits); !slices.Equal(got, tt.want) {
+				t.Fatalf("search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexPrefixScoresLower(t *testing.T) {
+	ix := newIndex()
+	ix.put("o1", "", "bell")
+	ix.put("o2", "", "bells")
+
+	hits := ix.search("bell")
+	if got := hitIDs(hits); !slices.Equal(got, []string{"o1", "o2"}) {
+		t.Fatalf("search(bell) = %q, want exact match first", got)
+	}
+	if hits[0].score <= hits[1].score {
+		t.Fatalf("exact score %v <= prefix score %v", hits[0].score, hits[1].score)
+	}
+}
+
+func TestIndexPutAndRemove(t *testing.T) {
+	ix := newIndex()
+	ix.put("o1", "Alice", "bicycle")
+	ix.put("o1", "Alice", "scooter")
+
+	if hits := ix.search("bicycle"); len(hits) != 0 {
+		t.Fatalf("search after re-put found stale term: %v", hits)
+	}
+	if hits := ix.search("scooter"); len(hits) != 1 {
+		t.Fatalf("search(scooter) = %v, want o1", hits)
+	}
+
+	ix.remove("o1")
+	ix.remove("o1")
+	if len(ix.postings) != 0 || len(ix.docs) != 0 || len(ix.terms) != 0 {
+		t.Fatalf("index not empty after remove: %d postings, %d docs, terms %q", len(ix.postings), len(ix.docs), ix.terms)
+	}
+}
+
+func TestSearchOrders(t *testing.T) {
+	s := NewService()
+	ctx := context.Background()
+	for _, id := range []string{"o1", "o2", "o3"} {
+		if _, err := s.CreateOrder(ctx, &orderpb.CreateOrderRequest{OrderId: id, CustomerName: "Alice", Description: "parcel " + id}); err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+	}
+	if _, err := s.UpdateOrder(ctx, &orderpb.UpdateOrderRequest{OrderId: "o3", CustomerName: "Bob"}); err != nil {
+		t.Fatalf("UpdateOrder() error = %v", err)
+	}
+	if _, err := s.DeleteOrder(ctx, &orderpb.DeleteOrderRequest{OrderId: "o2"}); err != nil {
+		t.Fatalf("DeleteOrder() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		req       *orderpb.SearchOrdersRequest
+		want      codes.Code
+		wantIDs   []string
+		wantTotal int32
+	}{
+		{"updated and deleted orders drop out", &orderpb.SearchOrdersRequest{Query: "alice"}, codes.OK, []string{"o1"}, 1},
+		{"updated fields are found", &orderpb.SearchOrdersRequest{Query: "bob"}, codes.OK, []string{"o3"}, 1},
+		{"paged", &orderpb.SearchOrdersRequest{Query: "parcel", Page: 2, PageSize: 1}, codes.OK, []string{"o3"}, 2},
+		{"page past the end", &orderpb.SearchOrdersRequest{Query: "parcel", Page: 5, PageSize: 1}, codes.OK, nil, 2},
+		{"empty query", &orderpb.SearchOrdersRequest{Query: " - "}, codes.InvalidArgument, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.SearchOrders(ctx, tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("SearchOrders() code = %s, want %s (error %v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			var ids []string
+			for _, r := range resp.Results {
+				ids = append(ids, r.Order.OrderId)
+			}
+			if !slices.Equal(ids, tt.wantIDs) || resp.Total != tt.wantTotal {
+				t.Fatalf("SearchOrders() = %q of %d, want %q of %d", ids, resp.Total, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func hitIDs(hits []hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.id)
+	}
+	return ids
+}
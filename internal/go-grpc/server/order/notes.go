@@ -0,0 +1,89 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Service) AddOrderNote(ctx context.Context, req *orderpb.AddOrderNoteRequest) (*orderpb.AddOrderNoteResponse, error) {
+	logging.FromContext(ctx).Info("add order note", "order_id", req.OrderId, "author", req.Author, "visibility", req.Visibility.String())
+
+	if req.Author == "" {
+		return nil, status.Error(codes.InvalidArgument, "author is required")
+	}
+	if req.Body == "" {
+		return nil, status.Error(codes.InvalidArgument, "body is required")
+	}
+
+	switch req.Visibility {
+	case orderpb.NoteVisibility_NOTE_VISIBILITY_INTERNAL, orderpb.NoteVisibility_NOTE_VISIBILITY_CUSTOMER:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "visibility must be INTERNAL or CUSTOMER, got %s", req.Visibility)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[req.OrderId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	s.noteSeq++
+	now := timestamppb.Now()
+	note := &orderpb.OrderNote{
+		NoteId:     fmt.Sprintf("note-%d", s.noteSeq),
+		OrderId:    req.OrderId,
+		Author:     req.Author,
+		Body:       req.Body,
+		Visibility: req.Visibility,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	s.notes[req.OrderId] = append(s.notes[req.OrderId], note)
+
+	return &orderpb.AddOrderNoteResponse{
+		Success: true,
+		Message: "Note added successfully",
+		Note:    proto.Clone(note).(*orderpb.OrderNote),
+	}, nil
+}
+
+func (s *Service) ListOrderNotes(ctx context.Context, req *orderpb.ListOrderNotesRequest) (*orderpb.ListOrderNotesResponse, error) {
+	if _, ok := orderpb.NoteVisibility_name[int32(req.Visibility)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown visibility %d", req.Visibility)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.orders[req.OrderId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	notes := cloneNotes(s.notes[req.OrderId], req.Visibility)
+	return &orderpb.ListOrderNotesResponse{
+		Notes: notes,
+		Total: int32(len(notes)),
+	}, nil
+}
+
+// cloneNotes copies notes matching visibility, or all notes when visibility
+// is unspecified. Callers must hold s.mu.
+func cloneNotes(notes []*orderpb.OrderNote, visibility orderpb.NoteVisibility) []*orderpb.OrderNote {
+	out := make([]*orderpb.OrderNote, 0, len(notes))
+	for _, n := range notes {
+		if visibility != orderpb.NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED && n.Visibility != visibility {
+			continue
+		}
+		out = append(out, proto.Clone(n).(*orderpb.OrderNote))
+	}
+	return out
+}
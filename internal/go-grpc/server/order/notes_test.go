@@ -0,0 +1,131 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	internalNote = orderpb.NoteVisibility_NOTE_VISIBILITY_INTERNAL
+	customerNote = orderpb.NoteVisibility_NOTE_VISIBILITY_CUSTOMER
+)
+
+func TestAddOrderNote(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *orderpb.AddOrderNoteRequest
+		want codes.Code
+	}{
+		{"internal note", &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "call back", Visibility: internalNote}, codes.OK},
+		{"customer note", &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "on its way", Visibility: customerNote}, codes.OK},
+		{"missing author", &orderpb.AddOrderNoteRequest{OrderId: "o1", Body: "x", Visibility: internalNote}, codes.InvalidArgument},
+		{"missing body", &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Visibility: internalNote}, codes.InvalidArgument},
+		{"unspecified visibility", &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "x"}, codes.InvalidArgument},
+		{"unknown visibility", &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "x", Visibility: 42}, codes.InvalidArgument},
+		{"unknown order", &orderpb.AddOrderNoteRequest{OrderId: "o2", Author: "ann", Body: "x", Visibility: internalNote}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+
+			resp, err := s.AddOrderNote(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("AddOrderNote() code = %s, want %s (error %v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			n := resp.Note
+			if n.NoteId == "" || n.OrderId != "o1" || n.Visibility != tt.req.Visibility {
+				t.Errorf("note = %v", n)
+			}
+			if n.CreatedAt == nil || !n.UpdatedAt.AsTime().Equal(n.CreatedAt.AsTime()) {
+				t.Errorf("created_at = %v, updated_at = %v, want both set and equal", n.CreatedAt, n.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestListOrderNotes(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	for _, v := range []orderpb.NoteVisibility{internalNote, customerNote, internalNote} {
+		if _, err := s.AddOrderNote(ctx, &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "x", Visibility: v}); err != nil {
+			t.Fatalf("AddOrderNote() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        *orderpb.ListOrderNotesRequest
+		want       codes.Code
+		wantTotal  int32
+		wantNoteID string // first note
+	}{
+		{"all notes", &orderpb.ListOrderNotesRequest{OrderId: "o1"}, codes.OK, 3, "note-1"},
+		{"internal only", &orderpb.ListOrderNotesRequest{OrderId: "o1", Visibility: internalNote}, codes.OK, 2, "note-1"},
+		{"customer only", &orderpb.ListOrderNotesRequest{OrderId: "o1", Visibility: customerNote}, codes.OK, 1, "note-2"},
+		{"unknown visibility", &orderpb.ListOrderNotesRequest{OrderId: "o1", Visibility: 42}, codes.InvalidArgument, 0, ""},
+		{"unknown order", &orderpb.ListOrderNotesRequest{OrderId: "o2"}, codes.NotFound, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ListOrderNotes(ctx, tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("ListOrderNotes() code = %s, want %s (error %v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Total != tt.wantTotal || int32(len(resp.Notes)) != tt.wantTotal {
+				t.Fatalf("total = %d with %d notes, want %d", resp.Total, len(resp.Notes), tt.wantTotal)
+			}
+			if resp.Notes[0].NoteId != tt.wantNoteID {
+				t.Errorf("first note = %s, want %s", resp.Notes[0].NoteId, tt.wantNoteID)
+			}
+		})
+	}
+}
+
+func TestGetOrderView(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	if _, err := s.AddOrderNote(ctx, &orderpb.AddOrderNoteRequest{OrderId: "o1", Author: "ann", Body: "x", Visibility: internalNote}); err != nil {
+		t.Fatalf("AddOrderNote() error = %v", err)
+	}
+
+	tests := []struct {
+		view      orderpb.OrderView
+		wantNotes int
+	}{
+		{orderpb.OrderView_ORDER_VIEW_UNSPECIFIED, 0},
+		{orderpb.OrderView_ORDER_VIEW_BASIC, 0},
+		{orderpb.OrderView_ORDER_VIEW_FULL, 1},
+	}
+	for _, tt := range tests {
+		o, err := s.GetOrder(ctx, &orderpb.OrderRequest{OrderId: "o1", View: tt.view})
+		if err != nil {
+			t.Fatalf("GetOrder(%s) error = %v", tt.view, err)
+		}
+		if len(o.Notes) != tt.wantNotes {
+			t.Errorf("GetOrder(%s) returned %d notes, want %d", tt.view, len(o.Notes), tt.wantNotes)
+		}
+	}
+
+	// Notes go with their order.
+	if _, err := s.DeleteOrder(ctx, &orderpb.DeleteOrderRequest{OrderId: "o1"}); err != nil {
+		t.Fatalf("DeleteOrder() error = %v", err)
+	}
+	if _, err := s.CreateOrder(ctx, &orderpb.CreateOrderRequest{OrderId: "o1"}); err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	resp, err := s.ListOrderNotes(ctx, &orderpb.ListOrderNotesRequest{OrderId: "o1"})
+	if err != nil || resp.Total != 0 {
+		t.Fatalf("ListOrderNotes() after recreating the order = %v, %v, want no notes", resp, err)
+	}
+}
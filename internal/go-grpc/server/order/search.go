@@ -0,0 +1,39 @@
+package order
+
+import (
+	"context"
+
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Service) SearchOrders(ctx context.Context, req *orderpb.SearchOrdersRequest) (*orderpb.SearchOrdersResponse, error) {
+	logging.FromContext(ctx).Info("search orders", "query", req.Query, "page", req.Page, "page_size", req.PageSize)
+
+	if len(tokenize(req.Query)) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "query must contain at least one word")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hits := s.index.search(req.Query)
+	start, end := pageBounds(req.Page, req.PageSize, len(hits))
+
+	results := make([]*orderpb.SearchResult, 0, end-start)
+	for _, h := range hits[start:end] {
+		results = append(results, &orderpb.SearchResult{
+			Order: proto.Clone(s.orders[h.id]).(*orderpb.OrderResponse),
+			Score: h.score,
+		})
+	}
+
+	return &orderpb.SearchOrdersResponse{
+		Results: results,
+		Total:   int32(len(hits)),
+	}, nil
+}
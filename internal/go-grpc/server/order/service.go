@@ -2,34 +2,116 @@ package order
 
 import (
 	"context"
-	"log"
 	"os"
+	"sort"
+	"sync"
 
+	"github.com/braden0236/playground/internal/go-grpc/carrier"
+	"github.com/braden0236/playground/internal/go-grpc/server/operations"
 	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	StatusCreated   = "CREATED"
+	StatusShipped   = "SHIPPED"
+	StatusDelivered = "DELIVERED"
+
+	defaultPageSize = 10
 )
 
 type Service struct {
 	orderpb.UnimplementedOrderServiceServer
+
+	mu       sync.RWMutex
+	orders   map[string]*orderpb.OrderResponse
+	notes    map[string][]*orderpb.OrderNote
+	noteSeq  int
+	index    *index
+	carriers map[string]carrier.Carrier
+
+	operations *operations.Service
+}
+
+type Option func(*Service)
+
+func WithCarrier(c carrier.Carrier) Option {
+	return func(s *Service) {
+		s.carriers[c.Name()] = c
+	}
+}
+
+func WithOperations(ops *operations.Service) Option {
+	return func(s *Service) {
+		s.operations = ops
+	}
 }
 
-func NewService() *Service {
-	return &Service{}
+func NewService(opts ...Option) *Service {
+	s := &Service{
+		orders:   make(map[string]*orderpb.OrderResponse),
+		notes:    make(map[string][]*orderpb.OrderNote),
+		index:    newIndex(),
+		carriers: make(map[string]carrier.Carrier),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *Service) GetOrder(ctx context.Context, req *orderpb.OrderRequest) (*orderpb.OrderResponse, error) {
 	// log.Printf("GetOrder: %s", req.OrderId)
+	s.mu.RLock()
+	o, ok := s.orders[req.OrderId]
+	if ok {
+		o = proto.Clone(o).(*orderpb.OrderResponse)
+		if req.View == orderpb.OrderView_ORDER_VIEW_FULL {
+			o.Notes = cloneNotes(s.notes[req.OrderId], orderpb.NoteVisibility_NOTE_VISIBILITY_UNSPECIFIED)
+		}
+	}
+	s.mu.RUnlock()
+	if ok {
+		return o, nil
+	}
+
+	// Unknown orders keep answering with the serving host so the load
+	// balancing client can tell replicas apart.
 	hostname, _ := os.Hostname()
 
 	return &orderpb.OrderResponse{
-		OrderId: req.OrderId,
-		Status:  "SHIPPED",
-		Amount:  100.50,
+		OrderId:     req.OrderId,
+		Status:      StatusShipped,
+		Amount:      100.50,
 		Description: hostname,
 	}, nil
 }
 
 func (s *Service) CreateOrder(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.CreateOrderResponse, error) {
-	log.Printf("CreateOrder: %s, %.2f", req.OrderId, req.Amount)
+	logging.FromContext(ctx).Info("create order", "order_id", req.OrderId, "amount", req.Amount)
+
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[req.OrderId]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "order %s already exists", req.OrderId)
+	}
+	s.orders[req.OrderId] = &orderpb.OrderResponse{
+		OrderId:      req.OrderId,
+		Status:       StatusCreated,
+		Amount:       req.Amount,
+		Description:  req.Description,
+		CustomerName: req.CustomerName,
+	}
+	s.index.put(req.OrderId, req.CustomerName, req.Description)
 
 	return &orderpb.CreateOrderResponse{
 		Success: true,
@@ -38,7 +120,28 @@ func (s *Service) CreateOrder(ctx context.Context, req *orderpb.CreateOrderReque
 }
 
 func (s *Service) UpdateOrder(ctx context.Context, req *orderpb.UpdateOrderRequest) (*orderpb.UpdateOrderResponse, error) {
-	log.Printf("UpdateOrder: %s -> status=%s, amount=%.2f", req.OrderId, req.Status, req.Amount)
+	logging.FromContext(ctx).Info("update order", "order_id", req.OrderId, "status", req.Status, "amount", req.Amount)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[req.OrderId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+	if req.Status != "" {
+		o.Status = req.Status
+	}
+	if req.Amount != 0 {
+		o.Amount = req.Amount
+	}
+	if req.Description != "" {
+		o.Description = req.Description
+	}
+	if req.CustomerName != "" {
+		o.CustomerName = req.CustomerName
+	}
+	s.index.put(o.OrderId, o.CustomerName, o.Description)
 
 	return &orderpb.UpdateOrderResponse{
 		Success: true,
@@ -47,7 +150,17 @@ func (s *Service) UpdateOrder(ctx context.Context, req *orderpb.UpdateOrderReque
 }
 
 func (s *Service) DeleteOrder(ctx context.Context, req *orderpb.DeleteOrderRequest) (*orderpb.DeleteOrderResponse, error) {
-	log.Printf("DeleteOrder: %s", req.OrderId)
+	logging.FromContext(ctx).Info("delete order", "order_id", req.OrderId)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[req.OrderId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+	delete(s.orders, req.OrderId)
+	delete(s.notes, req.OrderId)
+	s.index.remove(req.OrderId)
 
 	return &orderpb.DeleteOrderResponse{
 		Success: true,
@@ -56,15 +169,45 @@ func (s *Service) DeleteOrder(ctx context.Context, req *orderpb.DeleteOrderReque
 }
 
 func (s *Service) ListOrders(ctx context.Context, req *orderpb.ListOrdersRequest) (*orderpb.ListOrdersResponse, error) {
-	log.Printf("ListOrders: page=%d, size=%d", req.Page, req.PageSize)
+	logging.FromContext(ctx).Debug("list orders", "page", req.Page, "page_size", req.PageSize)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	orders := []*orderpb.OrderResponse{
-		{OrderId: "order-001", Status: "CREATED", Amount: 123.45},
-		{OrderId: "order-002", Status: "DELIVERED", Amount: 456.78},
+	ids := make([]string, 0, len(s.orders))
+	for id := range s.orders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	start, end := pageBounds(req.Page, req.PageSize, len(ids))
+	orders := make([]*orderpb.OrderResponse, 0, end-start)
+	for _, id := range ids[start:end] {
+		orders = append(orders, proto.Clone(s.orders[id]).(*orderpb.OrderResponse))
 	}
 
 	return &orderpb.ListOrdersResponse{
 		Orders: orders,
-		Total:  int32(len(orders)),
+		Total:  int32(len(ids)),
 	}, nil
 }
+
+// pageBounds converts a 1-based page and page size into slice bounds over n
+// items, applying defaults for unset values.
+func pageBounds(page, size int32, n int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = defaultPageSize
+	}
+	start := int(page-1) * int(size)
+	if start > n {
+		start = n
+	}
+	end := start + int(size)
+	if end > n {
+		end = n
+	}
+	return start, end
+}
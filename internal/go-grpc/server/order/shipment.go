@@ -0,0 +1,179 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/braden0236/playground/internal/go-grpc/carrier"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Service) AttachShipment(ctx context.Context, req *orderpb.AttachShipmentRequest) (*orderpb.AttachShipmentResponse, error) {
+	logging.FromContext(ctx).Info("attach shipment", "order_id", req.OrderId, "carrier", req.Carrier, "tracking_number", req.TrackingNumber)
+
+	if req.TrackingNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "tracking_number is required")
+	}
+	c, ok := s.carriers[req.Carrier]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown carrier %q", req.Carrier)
+	}
+
+	s.mu.RLock()
+	_, ok = s.orders[req.OrderId]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	if err := c.Register(ctx, req.TrackingNumber); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "register shipment with %s: %v", c.Name(), err)
+	}
+
+	now := timestamppb.Now()
+	s.mu.Lock()
+	o, ok := s.orders[req.OrderId]
+	if !ok {
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+	o.Shipment = &orderpb.Shipment{
+		Carrier:        c.Name(),
+		TrackingNumber: req.TrackingNumber,
+		Status:         string(carrier.StatusLabelCreated),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	o.Status = StatusShipped
+	s.mu.Unlock()
+
+	shipment, _, err := s.refreshShipment(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.AttachShipmentResponse{
+		Success:  true,
+		Message:  "Shipment attached successfully",
+		Shipment: shipment,
+	}, nil
+}
+
+func (s *Service) GetTracking(ctx context.Context, req *orderpb.TrackingRequest) (*orderpb.TrackingResponse, error) {
+	shipment, orderStatus, err := s.refreshShipment(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.TrackingResponse{
+		OrderId:     req.OrderId,
+		OrderStatus: orderStatus,
+		Shipment:    shipment,
+	}, nil
+}
+
+// RefreshShipments polls the carrier of every undelivered shipment once.
+func (s *Service) RefreshShipments(ctx context.Context) {
+	s.mu.RLock()
+	var pending []string
+	for id, o := range s.orders {
+		if o.Shipment != nil && o.Status != StatusDelivered {
+			pending = append(pending, id)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, id := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, _, err := s.refreshShipment(ctx, id); err != nil {
+			slog.Warn("refresh shipment failed", "order_id", id, "error", err)
+		}
+	}
+}
+
+// TrackerRunFunc polls carriers every interval until interrupted, in the
+// shape expected by run.Group.
+func (s *Service) TrackerRunFunc(interval time.Duration) (func() error, func(error)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return func() error {
+			if interval <= 0 {
+				return fmt.Errorf("shipment poll interval must be positive, got %s", interval)
+			}
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					s.RefreshShipments(ctx)
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}, func(error) {
+			cancel()
+		}
+}
+
+func (s *Service) refreshShipment(ctx context.Context, orderID string) (*orderpb.Shipment, string, error) {
+	s.mu.RLock()
+	o, ok := s.orders[orderID]
+	if !ok {
+		s.mu.RUnlock()
+		return nil, "", status.Errorf(codes.NotFound, "order %s not found", orderID)
+	}
+	if o.Shipment == nil {
+		s.mu.RUnlock()
+		return nil, "", status.Errorf(codes.FailedPrecondition, "order %s has no shipment", orderID)
+	}
+	carrierName, trackingNumber := o.Shipment.Carrier, o.Shipment.TrackingNumber
+	s.mu.RUnlock()
+
+	c, ok := s.carriers[carrierName]
+	if !ok {
+		return nil, "", status.Errorf(codes.Internal, "carrier %q is no longer configured", carrierName)
+	}
+	events, err := c.Track(ctx, trackingNumber)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Unavailable, "track shipment with %s: %v", carrierName, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok = s.orders[orderID]
+	if !ok || o.Shipment == nil || o.Shipment.TrackingNumber != trackingNumber {
+		return nil, "", status.Errorf(codes.Aborted, "shipment of order %s changed while tracking", orderID)
+	}
+
+	// An empty answer after earlier events is a carrier hiccup, not a reset.
+	if len(events) > 0 && len(events) != len(o.Shipment.Events) {
+		o.Shipment.Events = make([]*orderpb.ShipmentEvent, 0, len(events))
+		for _, e := range events {
+			o.Shipment.Events = append(o.Shipment.Events, &orderpb.ShipmentEvent{
+				Status:      string(e.Status),
+				Location:    e.Location,
+				Description: e.Description,
+				Time:        timestamppb.New(e.Time),
+			})
+		}
+		o.Shipment.Status = string(events[len(events)-1].Status)
+		o.Shipment.UpdatedAt = timestamppb.Now()
+	}
+
+	if carrier.Delivered(events) && o.Status != StatusDelivered {
+		logging.FromContext(ctx).Info("order delivered", "order_id", orderID, "carrier", carrierName, "tracking_number", trackingNumber)
+		o.Status = StatusDelivered
+	}
+
+	return proto.Clone(o.Shipment).(*orderpb.Shipment), o.Status, nil
+}
@@ -0,0 +1,182 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/braden0236/playground/internal/go-grpc/carrier"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedCarrier answers Track with whatever the test set last.
+type scriptedCarrier struct {
+	mu          sync.Mutex
+	events      []carrier.Event
+	trackErr    error
+	registerErr error
+}
+
+func (c *scriptedCarrier) Name() string { return "scripted" }
+
+func (c *scriptedCarrier) Register(context.Context, string) error {
+	return c.registerErr
+}
+
+func (c *scriptedCarrier) Track(context.Context, string) ([]carrier.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.events, c.trackErr
+}
+
+func (c *scriptedCarrier) set(events []carrier.Event, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events, c.trackErr = events, err
+}
+
+// newTestService returns a Service holding the order "o1".
+func newTestService(t *testing.T, opts ...Option) *Service {
+	t.Helper()
+	s := NewService(opts...)
+	if _, err := s.CreateOrder(context.Background(), &orderpb.CreateOrderRequest{OrderId: "o1"}); err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	return s
+}
+
+func TestAttachShipment(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *orderpb.AttachShipmentRequest
+		registerErr error
+		want        codes.Code
+	}{
+		{"attached", &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "scripted", TrackingNumber: "T1"}, nil, codes.OK},
+		{"missing tracking number", &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "scripted"}, nil, codes.InvalidArgument},
+		{"unknown carrier", &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "pigeon", TrackingNumber: "T1"}, nil, codes.InvalidArgument},
+		{"unknown order", &orderpb.AttachShipmentRequest{OrderId: "o2", Carrier: "scripted", TrackingNumber: "T1"}, nil, codes.NotFound},
+		{"carrier unreachable", &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "scripted", TrackingNumber: "T1"}, errors.New("timeout"), codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &scriptedCarrier{
+				events:      []carrier.Event{{Status: carrier.StatusLabelCreated}},
+				registerErr: tt.registerErr,
+			}
+			s := newTestService(t, WithCarrier(c))
+
+			resp, err := s.AttachShipment(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("AttachShipment() code = %s, want %s (error %v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Shipment.GetStatus() != string(carrier.StatusLabelCreated) || len(resp.Shipment.GetEvents()) != 1 {
+				t.Errorf("shipment = %v, want one LABEL_CREATED event", resp.Shipment)
+			}
+			o, _ := s.GetOrder(context.Background(), &orderpb.OrderRequest{OrderId: "o1"})
+			if o.Status != StatusShipped {
+				t.Errorf("order status = %s, want %s", o.Status, StatusShipped)
+			}
+		})
+	}
+}
+
+func TestGetTracking(t *testing.T) {
+	c := &scriptedCarrier{events: []carrier.Event{{Status: carrier.StatusLabelCreated}}}
+	s := newTestService(t, WithCarrier(c))
+	ctx := context.Background()
+
+	if _, err := s.GetTracking(ctx, &orderpb.TrackingRequest{OrderId: "o1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("GetTracking() without shipment error = %v, want FailedPrecondition", err)
+	}
+	if _, err := s.AttachShipment(ctx, &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "scripted", TrackingNumber: "T1"}); err != nil {
+		t.Fatalf("AttachShipment() error = %v", err)
+	}
+
+	steps := []struct {
+		name        string
+		events      []carrier.Event
+		err         error
+		wantCode    codes.Code
+		wantEvents  int
+		wantStatus  string
+		orderStatus string
+	}{
+		{"in transit", []carrier.Event{{Status: carrier.StatusLabelCreated}, {Status: carrier.StatusInTransit}}, nil, codes.OK, 2, "IN_TRANSIT", StatusShipped},
+		{"empty answer keeps the known events", nil, nil, codes.OK, 2, "IN_TRANSIT", StatusShipped},
+		{"carrier error", nil, errors.New("down"), codes.Unavailable, 0, "", ""},
+		{"delivered", []carrier.Event{{Status: carrier.StatusLabelCreated}, {Status: carrier.StatusInTransit}, {Status: carrier.StatusDelivered}}, nil, codes.OK, 3, "DELIVERED", StatusDelivered},
+	}
+	for _, st := range steps {
+		c.set(st.events, st.err)
+		resp, err := s.GetTracking(ctx, &orderpb.TrackingRequest{OrderId: "o1"})
+		if got := status.Code(err); got != st.wantCode {
+			t.Fatalf("%s: code = %s, want %s (error %v)", st.name, got, st.wantCode, err)
+		}
+		if err != nil {
+			continue
+		}
+		if got := len(resp.Shipment.Events); got != st.wantEvents {
+			t.Errorf("%s: %d events, want %d", st.name, got, st.wantEvents)
+		}
+		if resp.Shipment.Status != st.wantStatus {
+			t.Errorf("%s: shipment status = %s, want %s", st.name, resp.Shipment.Status, st.wantStatus)
+		}
+		if resp.OrderStatus != st.orderStatus {
+			t.Errorf("%s: order status = %s, want %s", st.name, resp.OrderStatus, st.orderStatus)
+		}
+	}
+
+	if _, err := s.GetTracking(ctx, &orderpb.TrackingRequest{OrderId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetTracking() of unknown order error = %v, want NotFound", err)
+	}
+}
+
+func TestRefreshShipmentsSkipsDelivered(t *testing.T) {
+	c := &scriptedCarrier{events: []carrier.Event{{Status: carrier.StatusDelivered}}}
+	s := newTestService(t, WithCarrier(c))
+	ctx := context.Background()
+	if _, err := s.AttachShipment(ctx, &orderpb.AttachShipmentRequest{OrderId: "o1", Carrier: "scripted", TrackingNumber: "T1"}); err != nil {
+		t.Fatalf("AttachShipment() error = %v", err)
+	}
+
+	// A delivered order is not polled again, so a failing carrier leaves
+	// it untouched.
+	c.set(nil, errors.New("down"))
+	s.RefreshShipments(ctx)
+
+	o, _ := s.GetOrder(ctx, &orderpb.OrderRequest{OrderId: "o1"})
+	if o.Status != StatusDelivered || o.Shipment.Status != string(carrier.StatusDelivered) {
+		t.Fatalf("order = %s/%s, want DELIVERED/DELIVERED", o.Status, o.Shipment.Status)
+	}
+}
+
+func TestTrackerRunFunc(t *testing.T) {
+	s := NewService()
+
+	execute, _ := s.TrackerRunFunc(0)
+	if err := execute(); err == nil {
+		t.Fatal("execute() with a zero interval succeeded, want an error")
+	}
+
+	execute, interrupt := s.TrackerRunFunc(time.Hour)
+	done := make(chan error, 1)
+	go func() { done <- execute() }()
+	interrupt(nil)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("execute() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("execute() did not return after interrupt")
+	}
+}
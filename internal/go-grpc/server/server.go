@@ -2,27 +2,39 @@ package server
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net"
+	"time"
 
+	"github.com/braden0236/playground/internal/go-grpc/carrier"
 	"github.com/braden0236/playground/internal/go-grpc/config"
 	"github.com/braden0236/playground/internal/go-grpc/healthz"
-	"github.com/braden0236/playground/internal/go-grpc/tls"
+	"github.com/braden0236/playground/internal/go-grpc/server/operations"
 	"github.com/braden0236/playground/internal/go-grpc/server/order"
+	"github.com/braden0236/playground/pkg/admin"
 	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/logging"
+	"github.com/braden0236/playground/pkg/requestid"
 
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+const operationsStopTimeout = 5 * time.Second
+
 type Server struct {
 	grpcServer   *grpc.Server
-	listener     net.Listener
+	address      string
 	healthServer *healthz.Server
+	orderService *order.Service
+	operations   *operations.Service
+	pollInterval time.Duration
+	maintenance  *admin.Maintenance
 }
 
 func NewGRPCServer(cfg config.Server) (*Server, error) {
@@ -30,9 +42,18 @@ func NewGRPCServer(cfg config.Server) (*Server, error) {
 	prometheus.MustRegister(srvMetrics)
 
 	opts := []grpc.ServerOption{}
+	maintenance := admin.NewMaintenance()
 
-	var unaryInterceptors []grpc.UnaryServerInterceptor
-	var streamInterceptors []grpc.StreamServerInterceptor
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		requestid.UnaryServerInterceptor(),
+		logging.UnaryServerInterceptor(),
+		maintenanceUnaryInterceptor(maintenance),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		requestid.StreamServerInterceptor(),
+		logging.StreamServerInterceptor(),
+		maintenanceStreamInterceptor(maintenance),
+	}
 
 	if cfg.Metrics.Enabled {
 		unaryInterceptors = append(unaryInterceptors, srvMetrics.UnaryServerInterceptor())
@@ -45,7 +66,7 @@ func NewGRPCServer(cfg config.Server) (*Server, error) {
 	)
 
 	if cfg.UseTLS {
-		tlsConfig, err := tls.BuildServerConfig(cfg, cfg.ClientCertAuth)
+		tlsConfig, err := buildTLSConfig(cfg)
 		if err != nil {
 			return nil, err
 		}
@@ -53,35 +74,55 @@ func NewGRPCServer(cfg config.Server) (*Server, error) {
 	}
 
 	grpcSrv := grpc.NewServer(opts...)
-	orderpb.RegisterOrderServiceServer(grpcSrv, order.NewService())
+	opsSvc := operations.NewService(operations.WithRetention(cfg.Operations.Retention))
+	longrunningpb.RegisterOperationsServer(grpcSrv, opsSvc)
+
+	orderSvc := order.NewService(
+		order.WithCarrier(carrier.NewFake(cfg.Shipping.FakeCarrierStep)),
+		order.WithOperations(opsSvc),
+	)
+	orderpb.RegisterOrderServiceServer(grpcSrv, orderSvc)
 
 	healthSrv := healthz.New()
 	grpc_health_v1.RegisterHealthServer(grpcSrv, healthSrv)
+	maintenance.OnChange(healthSrv.SetMaintenance)
 	reflection.Register(grpcSrv)
 
-	lis, err := net.Listen("tcp", cfg.Address)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Server{
 		grpcServer:   grpcSrv,
-		listener:     lis,
+		address:      cfg.Address,
 		healthServer: healthSrv,
+		orderService: orderSvc,
+		operations:   opsSvc,
+		pollInterval: cfg.Shipping.PollInterval,
+		maintenance:  maintenance,
 	}, nil
 }
 
 func (s *Server) Run() error {
-	log.Printf("gRPC server listening on %s", s.listener.Addr())
-    return s.grpcServer.Serve(s.listener)
+	lis, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("gRPC server listening", "address", lis.Addr().String())
+    return s.grpcServer.Serve(lis)
 }
 
 func (s *Server) Stop(ctx context.Context) error {
-	log.Println("Shutting down gRPC server gracefully")
+	slog.Info("shutting down gRPC server gracefully")
+	s.prepareStop()
     s.grpcServer.GracefulStop()
     return nil
 }
 
+// prepareStop reports NOT_SERVING and cancels running operations so that
+// waiting RPCs can complete before the transport is drained.
+func (s *Server) prepareStop() {
+	s.healthServer.SetNotReady()
+	s.operations.CancelAll(operationsStopTimeout)
+}
+
 func (s *Server) RunFunc() (func() error, func(error)) {
     return func() error {
             return s.Run()
@@ -89,3 +130,13 @@ func (s *Server) RunFunc() (func() error, func(error)) {
             _ = s.Stop(context.Background())
         }
 }
+
+// Maintenance returns the switch that makes the server reject everything but
+// health checks and reflection with UNAVAILABLE.
+func (s *Server) Maintenance() *admin.Maintenance {
+	return s.maintenance
+}
+
+func (s *Server) TrackerRunFunc() (func() error, func(error)) {
+	return s.orderService.TrackerRunFunc(s.pollInterval)
+}
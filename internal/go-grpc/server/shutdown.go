@@ -2,13 +2,13 @@ package server
 
 import (
 	"context"
-	"log"
+	"log/slog"
 )
 
 func WaitForShutdown(ctx context.Context, stop context.CancelFunc) (func() error, func(error)) {
     return func() error {
             <-ctx.Done()
-            log.Println("WaitForShutdown: received shutdown signal, exiting gracefully")
+            slog.Info("received shutdown signal, exiting gracefully")
             return nil
         }, func(error) {
             stop()
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"crypto/tls"
+
+	"github.com/braden0236/playground/internal/go-grpc/config"
+	"github.com/braden0236/playground/pkg/tlsutil"
+)
+
+// buildTLSConfig requires and verifies client certs when ClientCertAuth is
+// set.
+func buildTLSConfig(cfg config.Server) (*tls.Config, error) {
+	clientAuth := tls.NoClientCert
+	if cfg.ClientCertAuth {
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsutil.BuildServerConfig(cfg, tlsutil.WithClientAuth(clientAuth))
+}
@@ -0,0 +1,189 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/braden0236/playground/pkg/logging"
+)
+
+// Prefix is the path all admin routes are served under.
+const Prefix = "/admin"
+
+type Admin struct {
+	config      any
+	maintenance *Maintenance
+	username    string
+	password    string
+}
+
+type Option func(*Admin)
+
+// WithConfig exposes cfg, with secrets redacted, at /admin/config.
+func WithConfig(cfg any) Option {
+	return func(a *Admin) {
+		a.config = cfg
+	}
+}
+
+func WithMaintenance(m *Maintenance) Option {
+	return func(a *Admin) {
+		a.maintenance = m
+	}
+}
+
+// WithBasicAuth protects all admin routes. Without both a username and a
+// password the admin routes are disabled.
+func WithBasicAuth(username, password string) Option {
+	return func(a *Admin) {
+		a.username = username
+		a.password = password
+	}
+}
+
+func New(opts ...Option) *Admin {
+	a := &Admin{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.maintenance == nil {
+		a.maintenance = NewMaintenance()
+	}
+	return a
+}
+
+func (a *Admin) Maintenance() *Maintenance {
+	return a.maintenance
+}
+
+// Enabled reports whether credentials are set. Handler answers 404 to
+// everything when they are not, so a missing setting never exposes the
+// routes.
+func (a *Admin) Enabled() bool {
+	return a.username != "" && a.password != ""
+}
+
+// Handler serves the admin routes on their full paths, so it can be mounted
+// at Prefix+"/" on a ServeMux or behind a gin wildcard route.
+func (a *Admin) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Prefix+"/loglevel", a.getLogLevel)
+	mux.HandleFunc("PUT "+Prefix+"/loglevel", a.setLogLevel)
+	mux.HandleFunc("GET "+Prefix+"/config", a.getConfig)
+	mux.HandleFunc("GET "+Prefix+"/buildinfo", a.getBuildInfo)
+	mux.HandleFunc("GET "+Prefix+"/maintenance", a.getMaintenance)
+	mux.HandleFunc("PUT "+Prefix+"/maintenance", a.setMaintenance)
+
+	if !a.Enabled() {
+		return http.NotFoundHandler()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(a.username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(a.password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+type logLevel struct {
+	Level string `json:"level"`
+}
+
+func (a *Admin) getLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, logLevel{Level: logging.Level()})
+}
+
+func (a *Admin) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevel
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	old := logging.Level()
+	if err := logging.SetLevel(req.Level); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	slog.Warn("log level changed", "from", old, "to", logging.Level(), "remote_addr", r.RemoteAddr)
+	writeJSON(w, http.StatusOK, logLevel{Level: logging.Level()})
+}
+
+func (a *Admin) getConfig(w http.ResponseWriter, r *http.Request) {
+	if a.config == nil {
+		writeError(w, http.StatusNotFound, "no config registered")
+		return
+	}
+	writeJSON(w, http.StatusOK, Redact(a.config))
+}
+
+type buildInfo struct {
+	GoVersion string `json:"go_version"`
+	Path      string `json:"path"`
+	Version   string `json:"version"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+func (a *Admin) getBuildInfo(w http.ResponseWriter, r *http.Request) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		writeError(w, http.StatusNotFound, "build info not available")
+		return
+	}
+
+	info := buildInfo{
+		GoVersion: bi.GoVersion,
+		Path:      bi.Main.Path,
+		Version:   bi.Main.Version,
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.Time = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+type maintenanceState struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (a *Admin) getMaintenance(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, maintenanceState{Enabled: a.maintenance.Enabled()})
+}
+
+func (a *Admin) setMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req maintenanceState
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if a.maintenance.Set(req.Enabled) {
+		slog.Warn("maintenance mode changed", "enabled", req.Enabled, "remote_addr", r.RemoteAddr)
+	}
+	writeJSON(w, http.StatusOK, maintenanceState{Enabled: a.maintenance.Enabled()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		user     string
+		password string
+		want     int
+	}{
+		{"no credentials configured", nil, "", "", http.StatusNotFound},
+		{"only a username configured", []Option{WithBasicAuth("admin", "")}, "admin", "", http.StatusNotFound},
+		{"missing credentials", []Option{WithBasicAuth("admin", "secret")}, "", "", http.StatusUnauthorized},
+		{"wrong password", []Option{WithBasicAuth("admin", "secret")}, "admin", "nope", http.StatusUnauthorized},
+		{"valid credentials", []Option{WithBasicAuth("admin", "secret")}, "admin", "secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(tt.opts...)
+			req := httptest.NewRequest(http.MethodPut, Prefix+"/maintenance", strings.NewReader(`{"enabled":true}`))
+			if tt.user != "" {
+				req.SetBasicAuth(tt.user, tt.password)
+			}
+			w := httptest.NewRecorder()
+			a.Handler().ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if enabled := a.Maintenance().Enabled(); enabled != (tt.want == http.StatusOK) {
+				t.Fatalf("maintenance enabled = %v after status %d", enabled, w.Code)
+			}
+		})
+	}
+}
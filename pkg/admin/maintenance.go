@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrMaintenance is returned by readiness checks while maintenance mode is
+// on.
+var ErrMaintenance = errors.New("maintenance mode")
+
+// Maintenance is a switch that servers consult to reject traffic while an
+// operator works on the service.
+type Maintenance struct {
+	mu       sync.RWMutex
+	enabled  bool
+	onChange []func(enabled bool)
+}
+
+func NewMaintenance() *Maintenance {
+	return &Maintenance{}
+}
+
+func (m *Maintenance) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Set switches maintenance mode and reports whether the state changed.
+// OnChange callbacks run only on a change.
+func (m *Maintenance) Set(enabled bool) bool {
+	m.mu.Lock()
+	if m.enabled == enabled {
+		m.mu.Unlock()
+		return false
+	}
+	m.enabled = enabled
+	callbacks := m.onChange
+	m.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(enabled)
+	}
+	return true
+}
+
+func (m *Maintenance) OnChange(fn func(enabled bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = append(m.onChange, fn)
+}
+
+// Check is a readiness check that fails while maintenance mode is on.
+func (m *Maintenance) Check() error {
+	if m.Enabled() {
+		return ErrMaintenance
+	}
+	return nil
+}
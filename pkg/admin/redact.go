@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"reflect"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+var secretFields = []string{"password", "secret", "token", "apikey"}
+
+// Redact converts a config struct into a JSON-friendly map, replacing
+// non-empty string fields whose names look like secrets.
+func Redact(v any) any {
+	return redactValue(reflect.ValueOf(v))
+}
+
+func redactValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]any)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			if f.Anonymous && fv.Kind() == reflect.Struct {
+				out[f.Name] = redactValue(fv)
+				continue
+			}
+			if isSecret(f.Name) && fv.Kind() == reflect.String {
+				if fv.String() != "" {
+					out[f.Name] = redacted
+				} else {
+					out[f.Name] = ""
+				}
+				continue
+			}
+			out[f.Name] = redactValue(fv)
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k := iter.Key()
+			key := k.String()
+			if k.Kind() != reflect.String {
+				continue
+			}
+			if isSecret(key) {
+				out[key] = redacted
+				continue
+			}
+			out[key] = redactValue(iter.Value())
+		}
+		return out
+	default:
+		if s, ok := v.Interface().(interface{ String() string }); ok && v.Kind() == reflect.Int64 {
+			// time.Duration and friends read better as strings.
+			return s.String()
+		}
+		return v.Interface()
+	}
+}
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretFields {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+// Package app builds the gin server and its background actors from
+// configuration, so cmd/go-gin and the combined mode of cmd/go-grpc serve
+// the same HTTP API.
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/braden0236/playground/pkg/admin"
+	"github.com/braden0236/playground/pkg/go-gin/auth"
+	"github.com/braden0236/playground/pkg/go-gin/bodylimit"
+	"github.com/braden0236/playground/pkg/go-gin/compress"
+	"github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/go-gin/cors"
+	"github.com/braden0236/playground/pkg/go-gin/etag"
+	"github.com/braden0236/playground/pkg/go-gin/metric"
+	"github.com/braden0236/playground/pkg/go-gin/openapi"
+	"github.com/braden0236/playground/pkg/go-gin/ratelimit"
+	"github.com/braden0236/playground/pkg/go-gin/server"
+	"github.com/braden0236/playground/pkg/go-gin/sse"
+
+	"github.com/oklog/run"
+)
+
+// probePaths are never authenticated, rate limited or tagged.
+var probePaths = []string{"/healthz", "/livez", "/readyz", "/metrics"}
+
+type App struct {
+	Server *server.Server
+
+	keyStore *auth.KeyStore
+	poller   *server.OrderPoller
+}
+
+type Option func(*options)
+
+type options struct {
+	metricOpts []metric.Option
+	admin      *admin.Admin
+	serverOpts []server.Option
+}
+
+// WithMetricOptions is applied after the defaults, which ignore OPTIONS and
+// HEAD requests, protect /metrics with the configured credentials and add
+// the runtime collectors.
+func WithMetricOptions(opts ...metric.Option) Option {
+	return func(o *options) {
+		o.metricOpts = append(o.metricOpts, opts...)
+	}
+}
+
+// WithAdmin serves a instead of the default admin routes, which only expose
+// the configuration.
+func WithAdmin(a *admin.Admin) Option {
+	return func(o *options) {
+		o.admin = a
+	}
+}
+
+// WithServerOptions adds server options after the ones built from conf.
+func WithServerOptions(opts ...server.Option) Option {
+	return func(o *options) {
+		o.serverOpts = append(o.serverOpts, opts...)
+	}
+}
+
+func New(conf *config.Config, opts ...Option) (*App, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	metrics := metric.NewMetrics(append([]metric.Option{
+		metric.WithIgnoredMethods(http.MethodOptions, http.MethodHead),
+		metric.WithBasicAuth(conf.Metrics.Username, conf.Metrics.Password),
+		metric.WithRuntimeMetrics(),
+	}, o.metricOpts...)...)
+
+	adm := o.admin
+	if adm == nil {
+		adm = admin.New(
+			admin.WithConfig(conf),
+			admin.WithBasicAuth(conf.Metrics.Username, conf.Metrics.Password),
+		)
+	}
+
+	a := &App{}
+	srvOpts := []server.Option{
+		server.WithAdmin(adm),
+		server.WithTrustedProxies(conf.Server.TrustedProxies...),
+	}
+	if conf.OpenAPI.Enabled {
+		srvOpts = append(srvOpts, server.WithOpenAPI(openapi.New(
+			openapi.WithTitle(conf.OpenAPI.Title),
+			openapi.WithVersion(conf.OpenAPI.Version),
+			openapi.WithExclude(admin.Prefix+"/"),
+		)))
+		if conf.OpenAPI.SwaggerUI {
+			srvOpts = append(srvOpts, server.WithSwaggerUI())
+		}
+	}
+
+	bodyOpts := []bodylimit.Option{}
+	for _, r := range conf.Server.BodyLimits {
+		bodyOpts = append(bodyOpts, bodylimit.WithRouteLimit(r.Method, r.Path, r.Limit))
+	}
+	srvOpts = append(srvOpts, server.WithMiddleware(server.BeforeMetrics, bodylimit.New(conf.Server.MaxBodyBytes, bodyOpts...).Middleware()))
+
+	if conf.CORS.Enabled() {
+		corsOpts := []cors.Option{
+			cors.WithOrigins(conf.CORS.AllowedOrigins...),
+			cors.WithMethods(conf.CORS.AllowedMethods...),
+			cors.WithHeaders(conf.CORS.AllowedHeaders...),
+			cors.WithExposedHeaders(conf.CORS.ExposedHeaders...),
+			cors.WithMaxAge(conf.CORS.MaxAge),
+		}
+		if conf.CORS.AllowCredentials {
+			corsOpts = append(corsOpts, cors.WithCredentials())
+		}
+		policy, err := cors.New(corsOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS configuration: %w", err)
+		}
+		// Ahead of auth and rate limiting, so preflights are answered
+		// without credentials and rejections still carry CORS headers.
+		srvOpts = append(srvOpts, server.WithMiddleware(server.BeforeMetrics, policy.Middleware()))
+	}
+
+	if conf.Compression.Enabled {
+		compressOpts := []compress.Option{
+			compress.WithMinSize(conf.Compression.MinSize),
+			compress.WithLevel(conf.Compression.Level),
+		}
+		if conf.Compression.Brotli {
+			compressOpts = append(compressOpts, compress.WithBrotli())
+		}
+		if len(conf.Compression.ContentTypes) > 0 {
+			compressOpts = append(compressOpts, compress.WithContentTypes(conf.Compression.ContentTypes...))
+		}
+		srvOpts = append(srvOpts, server.WithMiddleware(server.BeforeMetrics, compress.New(compressOpts...).Middleware()))
+	}
+	if conf.ETag.Enabled {
+		// Inside compression, so the ETag is computed over the plain body.
+		srvOpts = append(srvOpts, server.WithMiddleware(server.BeforeMetrics, etag.New(
+			etag.WithMaxSize(conf.ETag.MaxSize),
+			etag.WithSkipPaths(probePaths...),
+		).Middleware()))
+	}
+
+	authSkip := slices.Concat(probePaths, []string{admin.Prefix + "/", server.OpenAPIPath, server.SwaggerUIPath + "/"})
+	if conf.APIKey.Enabled {
+		keyStore, err := auth.NewKeyStore(conf.APIKey.File)
+		if err != nil {
+			return nil, fmt.Errorf("load API keys: %w", err)
+		}
+		a.keyStore = keyStore
+		keyOpts := []auth.Option{
+			auth.WithHeader(conf.APIKey.Header),
+			auth.WithRegisterer(metrics.Registerer()),
+			auth.WithSkipPaths(authSkip...),
+		}
+		if conf.JWT.Enabled {
+			keyOpts = append(keyOpts, auth.WithOptional())
+		}
+		for _, r := range conf.APIKey.Routes {
+			keyOpts = append(keyOpts, auth.WithRouteScopes(r.Method, r.Path, r.Scopes...))
+		}
+		srvOpts = append(srvOpts, server.WithMiddleware(server.AfterMetrics, auth.NewAPIKeys(keyStore, keyOpts...).Middleware()))
+	}
+
+	if conf.JWT.Enabled {
+		keys, err := auth.NewKeySet(context.Background(), conf.JWT.JWKS, auth.WithRefreshInterval(conf.JWT.RefreshInterval))
+		if err != nil {
+			return nil, fmt.Errorf("load JWKS: %w", err)
+		}
+		jwtOpts := []auth.Option{
+			auth.WithIssuer(conf.JWT.Issuer),
+			auth.WithAudience(conf.JWT.Audience),
+			auth.WithLeeway(conf.JWT.Leeway),
+			auth.WithAlgorithms(conf.JWT.Algorithms...),
+			auth.WithSkipPaths(authSkip...),
+		}
+		for _, r := range conf.JWT.Routes {
+			jwtOpts = append(jwtOpts, auth.WithRouteScopes(r.Method, r.Path, r.Scopes...))
+		}
+		srvOpts = append(srvOpts, server.WithMiddleware(server.AfterMetrics, auth.NewJWT(keys, jwtOpts...).Middleware()))
+	}
+
+	if conf.RateLimit.Enabled {
+		limitOpts := []ratelimit.Option{
+			ratelimit.WithSkipPaths(probePaths...),
+			ratelimit.WithRegisterer(metrics.Registerer()),
+		}
+		if conf.RateLimit.KeyBy == "subject" {
+			limitOpts = append(limitOpts, ratelimit.WithKeyFunc(ratelimit.BySubject))
+		}
+		for _, r := range conf.RateLimit.Routes {
+			limitOpts = append(limitOpts, ratelimit.WithRouteLimit(r.Method, r.Path, ratelimit.Limit{Rate: r.Rate, Burst: r.Burst}))
+		}
+		limiter := ratelimit.New(ratelimit.Limit{Rate: conf.RateLimit.Rate, Burst: conf.RateLimit.Burst}, limitOpts...)
+		srvOpts = append(srvOpts, server.WithMiddleware(server.AfterMetrics, limiter.Middleware()))
+	}
+
+	// Dialled last, so no other setting can fail with the connection open.
+	if conf.OrderService.Address != "" {
+		conn, err := server.DialOrderService(conf.OrderService)
+		if err != nil {
+			return nil, fmt.Errorf("connect to order service: %w", err)
+		}
+		var (
+			gatewayOpts []server.GatewayOption
+			broker      *sse.Broker
+		)
+		if conf.OrderEvents.Enabled {
+			broker = sse.NewBroker(
+				sse.WithHistory(conf.OrderEvents.History),
+				sse.WithRegisterer(metrics.Registerer()),
+			)
+			gatewayOpts = append(gatewayOpts, server.WithEventStream(broker,
+				sse.WithHeartbeat(conf.OrderEvents.Heartbeat),
+				sse.WithBuffer(conf.OrderEvents.Buffer),
+			))
+			if conf.OrderEvents.PollInterval == 0 {
+				gatewayOpts = append(gatewayOpts, server.WithWriteEvents(broker))
+			}
+			// Streams never finish on their own, so end them when shutdown
+			// starts rather than at its deadline.
+			srvOpts = append(srvOpts, server.WithOnShutdown(broker.Close))
+		}
+		gateway := server.NewOrderGateway(conn, conf.OrderService.Timeout, gatewayOpts...)
+		if broker != nil && conf.OrderEvents.PollInterval > 0 {
+			a.poller = server.NewOrderPoller(gateway, broker, conf.OrderEvents.PollInterval)
+		}
+		srvOpts = append(srvOpts,
+			server.WithModule(gateway),
+			server.WithChecker(gateway.Checker()),
+			server.WithShutdownHook(func(context.Context) error { return conn.Close() }, server.WithHookName("order-service-conn")),
+		)
+	}
+
+	a.Server = server.NewServer(metrics, append(srvOpts, o.serverOpts...)...)
+	return a, nil
+}
+
+// AddActors adds the API key reloader and the order event poller, when
+// configured, to g. The server itself is left to the caller, which decides
+// how it is served.
+func (a *App) AddActors(g *run.Group) {
+	if a.keyStore != nil {
+		g.Add(a.keyStore.RunFunc())
+	}
+	if a.poller != nil {
+		g.Add(a.poller.RunFunc())
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/go-gin/metric"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	conf, err := config.Init()
+	if err != nil {
+		t.Fatalf("config.Init() error = %v", err)
+	}
+	return conf
+}
+
+func TestNew(t *testing.T) {
+	type request struct {
+		method, path string
+		header       map[string]string
+		user, pass   string
+		want         int
+	}
+	tests := []struct {
+		name     string
+		setup    func(*config.Config)
+		opts     []Option
+		requests []request
+	}{
+		{
+			name: "metrics credentials",
+			setup: func(c *config.Config) {
+				c.Metrics.Username, c.Metrics.Password = "prom", "secret"
+			},
+			requests: []request{
+				{method: http.MethodGet, path: "/metrics", want: http.StatusUnauthorized},
+				{method: http.MethodGet, path: "/metrics", user: "prom", pass: "secret", want: http.StatusOK},
+				{method: http.MethodGet, path: "/admin/config", want: http.StatusUnauthorized},
+				{method: http.MethodGet, path: "/admin/config", user: "prom", pass: "secret", want: http.StatusOK},
+			},
+		},
+		{
+			name:  "admin routes disabled without metrics credentials",
+			setup: func(*config.Config) {},
+			requests: []request{
+				{method: http.MethodGet, path: "/admin/config", want: http.StatusNotFound},
+				{method: http.MethodGet, path: "/metrics", want: http.StatusOK},
+			},
+		},
+		{
+			name: "metric options override the configured credentials",
+			setup: func(c *config.Config) {
+				c.Metrics.Username, c.Metrics.Password = "prom", "secret"
+			},
+			opts: []Option{WithMetricOptions(metric.WithBasicAuth("grpc", "other"))},
+			requests: []request{
+				{method: http.MethodGet, path: "/metrics", user: "prom", pass: "secret", want: http.StatusUnauthorized},
+				{method: http.MethodGet, path: "/metrics", user: "grpc", pass: "other", want: http.StatusOK},
+			},
+		},
+		{
+			name: "CORS preflight",
+			setup: func(c *config.Config) {
+				c.CORS.AllowedOrigins = []string{"https://app.test"}
+			},
+			requests: []request{
+				{method: http.MethodOptions, path: "/v1/orders", header: map[string]string{
+					"Origin":                        "https://app.test",
+					"Access-Control-Request-Method": http.MethodGet,
+				}, want: http.StatusNoContent},
+			},
+		},
+		{
+			name: "rate limit",
+			setup: func(c *config.Config) {
+				c.RateLimit.Enabled, c.RateLimit.Rate, c.RateLimit.Burst = true, 0.001, 1
+			},
+			requests: []request{
+				{method: http.MethodGet, path: "/missing", want: http.StatusNotFound},
+				{method: http.MethodGet, path: "/missing", want: http.StatusTooManyRequests},
+				{method: http.MethodGet, path: "/healthz", want: http.StatusOK},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := testConfig(t)
+			tt.setup(conf)
+			a, err := New(conf, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			for _, r := range tt.requests {
+				req := httptest.NewRequest(r.method, r.path, nil)
+				for k, v := range r.header {
+					req.Header.Set(k, v)
+				}
+				if r.user != "" {
+					req.SetBasicAuth(r.user, r.pass)
+				}
+				w := httptest.NewRecorder()
+				a.Server.ServeHTTP(w, req)
+				if w.Code != r.want {
+					t.Fatalf("%s %s = %d, want %d", r.method, r.path, w.Code, r.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	conf := testConfig(t)
+	conf.APIKey.Enabled, conf.APIKey.File = true, t.TempDir()+"/missing.json"
+	if _, err := New(conf); err == nil {
+		t.Fatal("New() with a missing API key file succeeded")
+	}
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestKeyStore(t *testing.T, keys ...APIKey) *KeyStore {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewKeyStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestAPIKeysMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newTestKeyStore(t,
+		APIKey{Name: "billing", Hash: HashAPIKey("billing-key"), Scopes: []string{"orders:read"}},
+		APIKey{Name: "old", Hash: HashAPIKey("old-key"), ExpiresAt: time.Now().Add(-time.Hour)},
+	)
+
+	// Two instances in one process need no registerer of their own.
+	optional := NewAPIKeys(store, WithOptional())
+	a := NewAPIKeys(store, WithRouteScopes(http.MethodPost, "/orders", "orders:write"))
+
+	r := gin.New()
+	r.Use(a.Middleware())
+	ok := func(c *gin.Context) {
+		claims, _ := GetClaims(c)
+		c.String(http.StatusOK, claims.Subject)
+	}
+	r.GET("/orders", ok)
+	r.POST("/orders", ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		key        string
+		wantStatus int
+		wantKey    string
+		wantResult string
+	}{
+		{name: "valid", method: http.MethodGet, key: "billing-key", wantStatus: http.StatusOK, wantKey: "billing", wantResult: "accepted"},
+		{name: "missing", method: http.MethodGet, wantStatus: http.StatusUnauthorized, wantKey: "unknown", wantResult: "missing"},
+		{name: "unknown", method: http.MethodGet, key: "nope", wantStatus: http.StatusUnauthorized, wantKey: "unknown", wantResult: "invalid"},
+		{name: "expired", method: http.MethodGet, key: "old-key", wantStatus: http.StatusUnauthorized, wantKey: "old", wantResult: "expired"},
+		{name: "missing scope", method: http.MethodPost, key: "billing-key", wantStatus: http.StatusForbidden, wantKey: "billing", wantResult: "forbidden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			if tt.key != "" {
+				req.Header.Set(DefaultAPIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != "billing" {
+				t.Fatalf("subject = %q, want billing", w.Body.String())
+			}
+			if got := testutil.ToFloat64(a.requests.WithLabelValues(tt.wantKey, tt.wantResult)); got != 1 {
+				t.Fatalf("requests{key=%q,result=%q} = %v, want 1", tt.wantKey, tt.wantResult, got)
+			}
+		})
+	}
+
+	r = gin.New()
+	r.Use(optional.Middleware())
+	r.GET("/orders", func(c *gin.Context) {
+		_, authenticated := GetClaims(c)
+		c.JSON(http.StatusOK, authenticated)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "false" {
+		t.Fatalf("optional without key = %d %s, want 200 false", w.Code, w.Body)
+	}
+}
+
+func TestAPIKeyThenJWTRouteScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newTestKeyStore(t,
+		APIKey{Name: "reader", Hash: HashAPIKey("reader-key"), Scopes: []string{"orders:read"}},
+		APIKey{Name: "writer", Hash: HashAPIKey("writer-key"), Scopes: []string{"orders:read", "orders:write"}},
+	)
+	keys := NewAPIKeys(store, WithOptional())
+	// Only the JWT middleware knows the route scopes, as with
+	// JWT_ROUTE_SCOPES set and API_KEY_ROUTE_SCOPES unset.
+	j := newTestJWT(t, WithRouteScopes(http.MethodPost, "/orders", "orders:write"))
+
+	r := gin.New()
+	r.Use(keys.Middleware(), j.Middleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/orders", ok)
+	r.POST("/orders", ok)
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		want   int
+	}{
+		{"no credential", http.MethodPost, "", http.StatusUnauthorized},
+		{"key without route scopes", http.MethodGet, "reader-key", http.StatusNoContent},
+		{"key missing the JWT route scope", http.MethodPost, "reader-key", http.StatusForbidden},
+		{"key with the JWT route scope", http.MethodPost, "writer-key", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/orders", nil)
+			if tt.key != "" {
+				req.Header.Set(DefaultAPIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRefreshInterval = 15 * time.Minute
+	// minRefreshInterval bounds how often an unknown kid can force a
+	// reload, so garbage tokens can't hammer the JWKS endpoint.
+	minRefreshInterval = 30 * time.Second
+	fetchTimeout       = 10 * time.Second
+	maxJWKSSize        = 1 << 20
+)
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// KeySet holds the verification keys of a JWKS document loaded from a file
+// or an http(s) URL. Keys are reloaded every refresh interval and when a
+// token names an unknown kid, which picks up rotated keys.
+type KeySet struct {
+	source   string
+	client   *http.Client
+	interval time.Duration
+
+	mu       sync.RWMutex
+	keys     []jsonWebKey
+	loadedAt time.Time
+	triedAt  time.Time
+}
+
+type KeySetOption func(*KeySet)
+
+func WithRefreshInterval(d time.Duration) KeySetOption {
+	return func(k *KeySet) {
+		k.interval = d
+	}
+}
+
+func WithHTTPClient(c *http.Client) KeySetOption {
+	return func(k *KeySet) {
+		k.client = c
+	}
+}
+
+// NewKeySet loads source once and fails if it can't, so a bad path or URL is
+// caught at startup.
+func NewKeySet(ctx context.Context, source string, opts ...KeySetOption) (*KeySet, error) {
+	k := &KeySet{
+		source:   source,
+		client:   &http.Client{Timeout: fetchTimeout},
+		interval: DefaultRefreshInterval,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Key returns the key for kid usable with alg. An empty kid matches when
+// exactly one key fits alg.
+func (k *KeySet) Key(ctx context.Context, kid, alg string) (any, error) {
+	k.mu.RLock()
+	stale := time.Since(k.loadedAt) > k.interval
+	k.mu.RUnlock()
+
+	if stale {
+		k.tryRefresh(ctx)
+	}
+
+	if key, err := k.find(kid, alg); err == nil || !errors.Is(err, ErrKeyNotFound) {
+		return key, err
+	}
+
+	if !k.tryRefresh(ctx) {
+		return nil, ErrKeyNotFound
+	}
+	return k.find(kid, alg)
+}
+
+func (k *KeySet) find(kid, alg string) (any, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var found []any
+	for _, jwk := range k.keys {
+		if kid != "" && jwk.kid != kid {
+			continue
+		}
+		if jwk.alg != "" && jwk.alg != alg {
+			continue
+		}
+		if !keyFitsAlg(jwk.key, alg) {
+			continue
+		}
+		found = append(found, jwk.key)
+	}
+
+	switch {
+	case len(found) == 1:
+		return found[0], nil
+	case len(found) > 1 && kid != "":
+		return found[0], nil
+	case len(found) > 1:
+		return nil, fmt.Errorf("token has no kid and %d keys match %s", len(found), alg)
+	default:
+		return nil, ErrKeyNotFound
+	}
+}
+
+// tryRefresh reloads the key set unless that was attempted recently. It
+// reports whether a reload happened; failures keep the previous keys.
+func (k *KeySet) tryRefresh(ctx context.Context) bool {
+	k.mu.Lock()
+	if time.Since(k.triedAt) < minRefreshInterval {
+		k.mu.Unlock()
+		return false
+	}
+	k.triedAt = time.Now()
+	k.mu.Unlock()
+
+	if err := k.refresh(ctx); err != nil {
+		slog.Warn("JWKS refresh failed, keeping previous keys", "source", k.source, "error", err)
+		return false
+	}
+	return true
+}
+
+func (k *KeySet) refresh(ctx context.Context) error {
+	data, err := k.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("load JWKS from %s: %w", k.source, err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("parse JWKS from %s: %w", k.source, err)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.loadedAt = time.Now()
+	k.triedAt = k.loadedAt
+	k.mu.Unlock()
+
+	slog.Debug("JWKS loaded", "source", k.source, "keys", len(keys))
+	return nil
+}
+
+func (k *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(k.source, "http://") && !strings.HasPrefix(k.source, "https://") {
+		return os.ReadFile(k.source)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+type jsonWebKey struct {
+	kid string
+	alg string
+	key any // *rsa.PublicKey, *ecdsa.PublicKey or []byte
+}
+
+type rawJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// parseJWKS decodes the RSA, EC and symmetric keys of a JWKS document.
+// Encryption keys and unsupported key types are skipped.
+func parseJWKS(data []byte) ([]jsonWebKey, error) {
+	var doc struct {
+		Keys []rawJWK `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var keys []jsonWebKey
+	for _, raw := range doc.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", raw.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, jsonWebKey{kid: raw.Kid, alg: raw.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (r rawJWK) publicKey() (any, error) {
+	switch r.Kty {
+	case "RSA":
+		n, err := decodeBigInt(r.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeBigInt(r.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch r.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", r.Crv)
+		}
+		x, err := decodeBigInt(r.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeBigInt(r.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(r.K)
+		if err != nil {
+			return nil, fmt.Errorf("k: %w", err)
+		}
+		if len(k) == 0 {
+			return nil, errors.New("empty symmetric key")
+		}
+		return k, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func keyFitsAlg(key any, alg string) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(alg, "ES")
+	case []byte:
+		return strings.HasPrefix(alg, "HS")
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// allowRefresh lifts the minimum interval between reloads.
+func allowRefresh(k *KeySet) {
+	k.mu.Lock()
+	k.triedAt = time.Time{}
+	k.mu.Unlock()
+}
+
+func TestKeySetFind(t *testing.T) {
+	k := testKeys()
+	rsaWithAlg := rsaJWK("rsa-ps", k.rsa2)
+	rsaWithAlg["alg"] = "PS256"
+	srv := newJWKSServer(t,
+		rsaJWK("rsa-1", k.rsa),
+		rsaWithAlg,
+		ecJWK("ec-1", k.ec),
+		octJWK("hmac-1", k.hmac),
+		map[string]string{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		map[string]string{"kty": "OKP", "kid": "ed-1", "crv": "Ed25519", "x": "AQAB"},
+	)
+	ks, err := NewKeySet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		kid     string
+		alg     string
+		want    any
+		wantErr bool
+	}{
+		{name: "RSA by kid", kid: "rsa-1", alg: "RS256", want: &k.rsa.PublicKey},
+		{name: "RSA key for PSS", kid: "rsa-1", alg: "PS256", want: &k.rsa.PublicKey},
+		{name: "EC by kid", kid: "ec-1", alg: "ES256", want: &k.ec.PublicKey},
+		{name: "HMAC by kid", kid: "hmac-1", alg: "HS256", want: k.hmac},
+		{name: "no kid, one EC key", alg: "ES256", want: &k.ec.PublicKey},
+		{name: "no kid, several RSA keys", alg: "PS256", wantErr: true},
+		{name: "RSA kid used with HS256", kid: "rsa-1", alg: "HS256", wantErr: true},
+		{name: "EC kid used with RS256", kid: "ec-1", alg: "RS256", wantErr: true},
+		{name: "HMAC kid used with ES256", kid: "hmac-1", alg: "ES256", wantErr: true},
+		{name: "key pinned to PS256 used with RS256", kid: "rsa-ps", alg: "RS256", wantErr: true},
+		{name: "encryption keys are skipped", kid: "enc-1", alg: "RS256", wantErr: true},
+		{name: "unsupported key types are skipped", kid: "ed-1", alg: "EdDSA", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ks.find(tt.kid, tt.alg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("find() = %T, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("find() error = %v", err)
+			}
+			if !sameKey(got, tt.want) {
+				t.Fatalf("find() returned a different key")
+			}
+		})
+	}
+}
+
+func sameKey(a, b any) bool {
+	switch a := a.(type) {
+	case *rsa.PublicKey:
+		return a.Equal(b)
+	case *ecdsa.PublicKey:
+		return a.Equal(b)
+	case []byte:
+		b, ok := b.([]byte)
+		return ok && string(a) == string(b)
+	}
+	return false
+}
+
+func TestKeySetRotation(t *testing.T) {
+	k := testKeys()
+	srv := newJWKSServer(t, rsaJWK("rsa-1", k.rsa))
+	ks, err := NewKeySet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := NewJWT(ks, WithIssuer(testIssuer), WithAudience(testAudience))
+	newToken := sign(t, jwt.SigningMethodRS256, k.rsa2, "rsa-2", claimsFor(time.Hour, ""))
+
+	// The issuer starts signing with rsa-2 and publishes it.
+	srv.set(t, rsaJWK("rsa-1", k.rsa), rsaJWK("rsa-2", k.rsa2))
+
+	// Right after a load an unknown kid doesn't trigger another one.
+	if _, err := j.Validate(context.Background(), newToken); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Validate() error = %v, want %v", err, ErrKeyNotFound)
+	}
+	if got := srv.fetches.Load(); got != 1 {
+		t.Fatalf("fetches = %d, want 1", got)
+	}
+
+	allowRefresh(ks)
+	if _, err := j.Validate(context.Background(), newToken); err != nil {
+		t.Fatalf("Validate() after refresh error = %v", err)
+	}
+	if got := srv.fetches.Load(); got != 2 {
+		t.Fatalf("fetches = %d, want 2", got)
+	}
+
+	// Known kids don't cause reloads.
+	if _, err := j.Validate(context.Background(), newToken); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.fetches.Load(); got != 2 {
+		t.Fatalf("fetches = %d, want 2", got)
+	}
+
+	// rsa-1 is retired: once the set is stale it is reloaded and tokens
+	// signed with it are rejected.
+	srv.set(t, rsaJWK("rsa-2", k.rsa2))
+	ks.mu.Lock()
+	ks.loadedAt = time.Now().Add(-2 * DefaultRefreshInterval)
+	ks.mu.Unlock()
+	allowRefresh(ks)
+	oldToken := sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(time.Hour, ""))
+	if _, err := j.Validate(context.Background(), oldToken); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Validate() with retired kid error = %v, want %v", err, ErrKeyNotFound)
+	}
+}
+
+func TestKeySetFailedRefreshKeepsKeys(t *testing.T) {
+	k := testKeys()
+	srv := newJWKSServer(t, rsaJWK("rsa-1", k.rsa))
+	ks, err := NewKeySet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.mu.Lock()
+	srv.doc = []byte(`{"keys": []}`)
+	srv.mu.Unlock()
+	allowRefresh(ks)
+
+	if _, err := ks.Key(context.Background(), "unknown", "RS256"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Key() error = %v, want %v", err, ErrKeyNotFound)
+	}
+	if _, err := ks.Key(context.Background(), "rsa-1", "RS256"); err != nil {
+		t.Fatalf("Key() after failed refresh error = %v", err)
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	k := testKeys()
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	srv := newJWKSServer(t, ecJWK("ec-1", k.ec))
+
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{name: "URL", source: srv.URL},
+		{name: "file", source: write("ok.json", `{"keys":[{"kty":"oct","kid":"h","k":"c2VjcmV0"}]}`)},
+		{name: "missing file", source: filepath.Join(dir, "missing.json"), wantErr: true},
+		{name: "not JSON", source: write("bad.json", `keys`), wantErr: true},
+		{name: "no signing keys", source: write("enc.json", `{"keys":[{"kty":"oct","use":"enc","k":"c2VjcmV0"}]}`), wantErr: true},
+		{name: "EC point off the curve", source: write("curve.json", `{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`), wantErr: true},
+		{name: "RSA exponent too small", source: write("exp.json", `{"keys":[{"kty":"RSA","n":"AQAB","e":"AQ"}]}`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeySet(context.Background(), tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKeySet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
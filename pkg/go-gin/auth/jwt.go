@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ClaimsKey is the gin context key the validated claims are stored under.
+const ClaimsKey = "jwt_claims"
+
+// Claims are the registered claims of an access token plus its scopes,
+// taken from either the space separated "scope" claim or the "scp" array.
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope,omitempty"`
+	Scp   []string `json:"scp,omitempty"`
+}
+
+func (c *Claims) Scopes() []string {
+	if len(c.Scp) > 0 {
+		return c.Scp
+	}
+	return strings.Fields(c.Scope)
+}
+
+func (c *Claims) HasScopes(scopes ...string) bool {
+	have := c.Scopes()
+	for _, s := range scopes {
+		if !slices.Contains(have, s) {
+			return false
+		}
+	}
+	return true
+}
+
+type claimsContextKey struct{}
+
+// GetClaims returns the claims of the request's validated token.
+func GetClaims(c *gin.Context) (*Claims, bool) {
+	if v, ok := c.Get(ClaimsKey); ok {
+		claims, ok := v.(*Claims)
+		return claims, ok
+	}
+	return ClaimsFromContext(c.Request.Context())
+}
+
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
+
+func setClaims(c *gin.Context, claims *Claims) {
+	c.Set(ClaimsKey, claims)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), claimsContextKey{}, claims))
+}
+
+// authenticated returns the claims of an earlier auth middleware that
+// accepted the request, so chained middleware don't ask for a second
+// credential but still enforce their route scopes on it.
+func authenticated(c *gin.Context) (*Claims, bool) {
+	v, ok := c.Get(ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*Claims)
+	return claims, ok
+}
+
+// JWT validates bearer tokens against a KeySet.
+type JWT struct {
+	options
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+func NewJWT(keys *KeySet, opts ...Option) *JWT {
+	j := &JWT{
+		options: newOptions(opts),
+		keys:    keys,
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(j.algorithms),
+		jwt.WithLeeway(j.leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if j.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(j.audience))
+	}
+	j.parser = jwt.NewParser(parserOpts...)
+
+	return j
+}
+
+// Validate checks the signature and claims of a compact serialized token.
+func (j *JWT) Validate(ctx context.Context, token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := j.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return j.keys.Key(ctx, kid, t.Method.Alg())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// requests lacking the scopes configured for their route with 403. The
+// claims of accepted requests are available through GetClaims. Requests an
+// earlier auth middleware, e.g. APIKeys, accepted are only checked for the
+// route scopes.
+func (j *JWT) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if j.skip(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		if claims, ok := authenticated(c); ok {
+			if scopes := j.routeScopes(c); !claims.HasScopes(scopes...) {
+				forbidden(c, scopes)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			unauthorized(c, "", "missing bearer token")
+			return
+		}
+
+		claims, err := j.Validate(c.Request.Context(), token)
+		if err != nil {
+			c.Error(err)
+			unauthorized(c, "invalid_token", describe(err))
+			return
+		}
+
+		setClaims(c, claims)
+
+		if scopes := j.routeScopes(c); !claims.HasScopes(scopes...) {
+			forbidden(c, scopes)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScopes is per-route middleware for routes registered behind
+// Middleware.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			unauthorized(c, "", "missing bearer token")
+			return
+		}
+		if !claims.HasScopes(scopes...) {
+			forbidden(c, scopes)
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// describe turns a validation error into a message that is safe to return
+// to the caller.
+func describe(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "token expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return "token not valid yet"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "invalid issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "invalid audience"
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return "required claim missing"
+	case errors.Is(err, ErrKeyNotFound):
+		return "unknown signing key"
+	default:
+		return "invalid token"
+	}
+}
+
+func unauthorized(c *gin.Context, code, message string) {
+	challenge := `Bearer`
+	if code != "" {
+		challenge = fmt.Sprintf(`Bearer error=%q, error_description=%q`, code, message)
+	}
+	c.Header("WWW-Authenticate", challenge)
+	problem.Write(c, problem.Unauthorized(message))
+}
+
+func forbidden(c *gin.Context, scopes []string) {
+	c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, strings.Join(scopes, " ")))
+	problem.Write(c, problem.Forbidden("insufficient scope"))
+}
@@ -0,0 +1,346 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://issuer.test"
+	testAudience = "orders"
+)
+
+// testKeys are generated once per test binary; RSA generation is slow.
+var testKeys = sync.OnceValue(func() struct {
+	rsa, rsa2 *rsa.PrivateKey
+	ec        *ecdsa.PrivateKey
+	hmac      []byte
+} {
+	k := struct {
+		rsa, rsa2 *rsa.PrivateKey
+		ec        *ecdsa.PrivateKey
+		hmac      []byte
+	}{hmac: make([]byte, 32)}
+	var err error
+	if k.rsa, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+	if k.rsa2, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		panic(err)
+	}
+	if k.ec, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		panic(err)
+	}
+	if _, err = rand.Read(k.hmac); err != nil {
+		panic(err)
+	}
+	return k
+})
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, k *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": b64(k.N.Bytes()), "e": b64(big.NewInt(int64(k.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, k *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": b64(k.X.FillBytes(make([]byte, 32))), "y": b64(k.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func octJWK(kid string, k []byte) map[string]string {
+	return map[string]string{"kty": "oct", "kid": kid, "k": b64(k)}
+}
+
+// jwksServer serves a JWKS document that tests can replace, and counts how
+// often it was fetched.
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	doc     []byte
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, keys ...map[string]string) *jwksServer {
+	t.Helper()
+	s := &jwksServer{}
+	s.set(t, keys...)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(s.doc)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) set(t *testing.T, keys ...map[string]string) {
+	t.Helper()
+	doc, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	s.doc = doc
+	s.mu.Unlock()
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.Claims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func claimsFor(exp time.Duration, scope string) *Claims {
+	now := time.Now()
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Subject:   "alice",
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(exp)),
+		},
+		Scope: scope,
+	}
+}
+
+func newTestJWT(t *testing.T, opts ...Option) *JWT {
+	t.Helper()
+	k := testKeys()
+	srv := newJWKSServer(t, rsaJWK("rsa-1", k.rsa), ecJWK("ec-1", k.ec), octJWK("hmac-1", k.hmac))
+	keys, err := NewKeySet(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = append([]Option{WithIssuer(testIssuer), WithAudience(testAudience), WithLeeway(30 * time.Second)}, opts...)
+	return NewJWT(keys, opts...)
+}
+
+func TestJWTValidate(t *testing.T) {
+	k := testKeys()
+	j := newTestJWT(t)
+
+	with := func(fn func(c *Claims)) *Claims {
+		c := claimsFor(time.Hour, "")
+		fn(c)
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error // nil means valid
+		wantMsg string
+	}{
+		{
+			name:  "RS256",
+			token: sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(time.Hour, "")),
+		},
+		{
+			name:  "ES256",
+			token: sign(t, jwt.SigningMethodES256, k.ec, "ec-1", claimsFor(time.Hour, "")),
+		},
+		{
+			name:  "HS256",
+			token: sign(t, jwt.SigningMethodHS256, k.hmac, "hmac-1", claimsFor(time.Hour, "")),
+		},
+		{
+			name:  "no kid with a single matching key",
+			token: sign(t, jwt.SigningMethodES256, k.ec, "", claimsFor(time.Hour, "")),
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", with(func(c *Claims) { c.Issuer = "https://other.test" })),
+			wantErr: jwt.ErrTokenInvalidIssuer,
+			wantMsg: "invalid issuer",
+		},
+		{
+			name:    "wrong audience",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", with(func(c *Claims) { c.Audience = jwt.ClaimStrings{"billing"} })),
+			wantErr: jwt.ErrTokenInvalidAudience,
+			wantMsg: "invalid audience",
+		},
+		{
+			name:  "expired within leeway",
+			token: sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(-10*time.Second, "")),
+		},
+		{
+			name:    "expired beyond leeway",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(-time.Minute, "")),
+			wantErr: jwt.ErrTokenExpired,
+			wantMsg: "token expired",
+		},
+		{
+			name:    "missing exp",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", with(func(c *Claims) { c.ExpiresAt = nil })),
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+			wantMsg: "required claim missing",
+		},
+		{
+			name:    "issued in the future beyond leeway",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", with(func(c *Claims) { c.IssuedAt = jwt.NewNumericDate(time.Now().Add(time.Minute)) })),
+			wantErr: jwt.ErrTokenUsedBeforeIssued,
+			wantMsg: "token not valid yet",
+		},
+		{
+			name:    "signed by another key under a known kid",
+			token:   sign(t, jwt.SigningMethodRS256, k.rsa2, "rsa-1", claimsFor(time.Hour, "")),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+			wantMsg: "invalid token",
+		},
+		{
+			// Algorithm confusion: an HMAC token keyed with public RSA
+			// material must not be checked against the RSA key.
+			name:    "HS256 naming an RSA kid",
+			token:   sign(t, jwt.SigningMethodHS256, k.rsa.N.Bytes(), "rsa-1", claimsFor(time.Hour, "")),
+			wantErr: ErrKeyNotFound,
+			wantMsg: "unknown signing key",
+		},
+		{
+			name:    "ES256 naming an RSA kid",
+			token:   sign(t, jwt.SigningMethodES256, k.ec, "rsa-1", claimsFor(time.Hour, "")),
+			wantErr: ErrKeyNotFound,
+			wantMsg: "unknown signing key",
+		},
+		{
+			name:    "algorithm not allowed",
+			token:   sign(t, jwt.SigningMethodRS512, k.rsa, "rsa-1", claimsFor(time.Hour, "")),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+			wantMsg: "invalid token",
+		},
+		{
+			name:    "unsigned",
+			token:   sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claimsFor(time.Hour, "")),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+			wantMsg: "invalid token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := j.Validate(context.Background(), tt.token)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if claims.Subject != "alice" {
+					t.Fatalf("Validate() subject = %q, want alice", claims.Subject)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := describe(err); got != tt.wantMsg {
+				t.Fatalf("describe() = %q, want %q", got, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestJWTMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	k := testKeys()
+	j := newTestJWT(t,
+		WithRouteScopes(http.MethodPost, "/orders", "orders:write"),
+		WithSkipPaths("/healthz"),
+	)
+
+	r := gin.New()
+	r.Use(j.Middleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/healthz", ok)
+	r.GET("/orders", ok)
+	r.POST("/orders", ok)
+	r.DELETE("/orders", RequireScopes("orders:admin"), ok)
+
+	reader := sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(time.Hour, "orders:read"))
+	writer := sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(time.Hour, "orders:read orders:write"))
+	admin := sign(t, jwt.SigningMethodES256, k.ec, "ec-1", &Claims{
+		RegisteredClaims: claimsFor(time.Hour, "").RegisteredClaims,
+		Scp:              []string{"orders:admin"},
+	})
+	expired := sign(t, jwt.SigningMethodRS256, k.rsa, "rsa-1", claimsFor(-time.Hour, "orders:write"))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		want          int
+		wantChallenge string
+	}{
+		{"skipped path", http.MethodGet, "/healthz", "", http.StatusNoContent, ""},
+		{"missing token", http.MethodGet, "/orders", "", http.StatusUnauthorized, "Bearer"},
+		{"not a bearer token", http.MethodGet, "/orders", "Basic YTpi", http.StatusUnauthorized, "Bearer"},
+		{"garbage token", http.MethodGet, "/orders", "Bearer abc", http.StatusUnauthorized, `error="invalid_token"`},
+		{"expired token", http.MethodGet, "/orders", "Bearer " + expired, http.StatusUnauthorized, `error_description="token expired"`},
+		{"valid token without route scopes", http.MethodGet, "/orders", "Bearer " + reader, http.StatusNoContent, ""},
+		{"missing route scope", http.MethodPost, "/orders", "Bearer " + reader, http.StatusForbidden, `scope="orders:write"`},
+		{"route scope present", http.MethodPost, "/orders", "bearer " + writer, http.StatusNoContent, ""},
+		{"missing RequireScopes scope", http.MethodDelete, "/orders", "Bearer " + writer, http.StatusForbidden, `error="insufficient_scope"`},
+		{"RequireScopes satisfied by scp", http.MethodDelete, "/orders", "Bearer " + admin, http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.want, w.Body)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); !strings.Contains(got, tt.wantChallenge) {
+				t.Fatalf("WWW-Authenticate = %q, want it to contain %q", got, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func TestRequireScopesWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", RequireScopes("orders:read"), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	hashPrefix = "sha256:"
+	// reloadDelay coalesces the burst of events editors and ConfigMap
+	// updates produce into a single reload.
+	reloadDelay = 100 * time.Millisecond
+)
+
+// APIKey is an entry of the key file. Only the hash of the key is stored;
+// keys are expected to be long random strings, so an unsalted SHA-256 is
+// enough to keep them out of the file.
+type APIKey struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"` // "sha256:<hex>", see HashAPIKey
+	Scopes    []string  `json:"scopes,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+func (k *APIKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && now.After(k.ExpiresAt)
+}
+
+// HashAPIKey returns the value to put in the hash field of the key file,
+// the same as "sha256:" followed by the output of `printf %s KEY | sha256sum`.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// KeyStore holds the API keys of a JSON file of the form
+//
+//	{"keys": [{"name": "billing", "hash": "sha256:...", "scopes": ["orders:read"], "expires_at": "2026-01-01T00:00:00Z"}]}
+//
+// Run watches the file and swaps in its new contents; a file that fails to
+// parse leaves the previous keys in place.
+type KeyStore struct {
+	path string
+
+	mu   sync.RWMutex
+	keys []*APIKey
+	done chan struct{}
+}
+
+func NewKeyStore(path string) (*KeyStore, error) {
+	s := &KeyStore{path: path, done: make(chan struct{})}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Lookup returns the entry whose hash matches key.
+func (s *KeyStore) Lookup(key string) (*APIKey, bool) {
+	hash := []byte(HashAPIKey(key))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(hash, []byte(k.Hash)) == 1 {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+func (s *KeyStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.keys)
+}
+
+func (s *KeyStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("read API key file: %w", err)
+	}
+	keys, err := parseKeyFile(data)
+	if err != nil {
+		return fmt.Errorf("parse API key file %s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+	return nil
+}
+
+func parseKeyFile(data []byte) ([]*APIKey, error) {
+	var doc struct {
+		Keys []*APIKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(doc.Keys))
+	for i, k := range doc.Keys {
+		if k.Name == "" {
+			return nil, fmt.Errorf("key %d: name is required", i)
+		}
+		if _, dup := names[k.Name]; dup {
+			return nil, fmt.Errorf("key %q: duplicate name", k.Name)
+		}
+		names[k.Name] = struct{}{}
+
+		k.Hash = strings.ToLower(k.Hash)
+		digest, ok := strings.CutPrefix(k.Hash, hashPrefix)
+		if b, err := hex.DecodeString(digest); !ok || err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("key %q: hash must be %q followed by 64 hex digits", k.Name, hashPrefix)
+		}
+	}
+	return doc.Keys, nil
+}
+
+// Run reloads the key file whenever it changes until the store is stopped.
+// The directory is watched rather than the file so that atomic renames and
+// Kubernetes ConfigMap symlink swaps are noticed.
+func (s *KeyStore) Run() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-s.done:
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Has(fsnotify.Chmod) {
+				continue
+			}
+			reload = time.After(reloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("API key file watch error", "path", s.path, "error", err)
+		case <-reload:
+			reload = nil
+			s.reload()
+		}
+	}
+}
+
+func (s *KeyStore) reload() {
+	before := s.Len()
+	if err := s.load(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Mid-swap; the create event that follows triggers another reload.
+			return
+		}
+		slog.Error("API key file reload failed, keeping previous keys", "path", s.path, "error", err)
+		return
+	}
+	slog.Info("API key file reloaded", "path", s.path, "keys", s.Len(), "previous", before)
+}
+
+func (s *KeyStore) Stop() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+func (s *KeyStore) RunFunc() (func() error, func(error)) {
+	return s.Run, func(error) {
+		s.Stop()
+	}
+}
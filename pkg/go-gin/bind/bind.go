@@ -0,0 +1,272 @@
+package bind
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(fieldName)
+	return v
+}
+
+// fieldName reports fields by the name clients send them as.
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"uri", "form", "json"} {
+		if name, _, _ := strings.Cut(f.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// Bind is Decode for handlers without an error return: on failure it
+// writes the problem and returns false.
+func Bind[T any](c *gin.Context) (*T, bool) {
+	v, err := Decode[T](c)
+	if err != nil {
+		problem.Error(c, err)
+		return nil, false
+	}
+	return v, true
+}
+
+// Decode fills a T from the JSON body, the query string (form tags) and the
+// path parameters (uri tags), in that order, and checks its validate tags.
+// Every field that fails to decode or validate is reported in a single
+// problem.Invalid; oversized bodies return *http.MaxBytesError.
+func Decode[T any](c *gin.Context) (*T, error) {
+	v := new(T)
+
+	var errs []problem.FieldError
+	if err := decodeBody(c, v); err != nil {
+		var p *problem.Problem
+		if !errors.As(err, &p) || len(p.Errors) == 0 {
+			return nil, err
+		}
+		errs = p.Errors
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct {
+		if len(errs) > 0 {
+			return nil, problem.Invalid(errs...)
+		}
+		return v, nil
+	}
+
+	errs = append(errs, decodeValues(rv, "form", "query", c.Request.URL.Query())...)
+	errs = append(errs, decodeValues(rv, "uri", "path", pathParams(c))...)
+
+	err := Validate(v)
+	var p *problem.Problem
+	switch {
+	case errors.As(err, &p):
+		// A field that didn't decode is reported once, as a type error.
+		for _, fe := range p.Errors {
+			if !hasField(errs, fe.Field) {
+				errs = append(errs, fe)
+			}
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	if len(errs) > 0 {
+		return nil, problem.Invalid(errs...)
+	}
+	return v, nil
+}
+
+// Validate checks the validate tags of v, returning problem.Invalid for
+// invalid fields.
+func Validate(v any) error {
+	err := validate.Struct(v)
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	errs := make([]problem.FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		errs = append(errs, problem.FieldError{
+			Field:   field,
+			In:      location(t, fe.StructNamespace()),
+			Code:    fe.Tag(),
+			Message: message(fe),
+		})
+	}
+	return problem.Invalid(errs...)
+}
+
+func decodeBody(c *gin.Context, v any) error {
+	body := c.Request.Body
+	if body == nil || body == http.NoBody {
+		return nil
+	}
+	if ct := c.ContentType(); ct != "" && !isJSON(ct) {
+		return problem.Newf(http.StatusUnsupportedMediaType, "content type %s is not JSON", ct)
+	}
+
+	dec := json.NewDecoder(body)
+	var err error
+	// Fields only tagged form or uri must not be set from the body.
+	if fields := bodyFieldsOf(reflect.TypeOf(v).Elem()); len(fields.queryOnly) > 0 {
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err == nil {
+			err = json.Unmarshal(fields.strip(raw), v)
+		}
+	} else {
+		err = dec.Decode(v)
+	}
+	var (
+		tooLarge  *http.MaxBytesError
+		wrongType *json.UnmarshalTypeError
+	)
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &tooLarge):
+		return tooLarge
+	case errors.As(err, &wrongType) && wrongType.Field != "":
+		return problem.Invalid(problem.FieldError{
+			Field:   wrongType.Field,
+			In:      "body",
+			Code:    "type",
+			Message: "must be " + describe(wrongType.Type),
+		})
+	}
+	return problem.BadRequest("invalid JSON body: " + err.Error())
+}
+
+var bodyFieldsCache sync.Map // reflect.Type -> bodyFields
+
+// bodyFields tells how encoding/json matches keys to the fields of a struct
+// type: a key naming a field exactly goes to it, otherwise any field whose
+// name matches case-insensitively may take it, including fields that are
+// only tagged form or uri.
+type bodyFields struct {
+	names     map[string]bool // JSON names of the body fields
+	queryOnly []string        // Go names of fields only tagged form or uri
+}
+
+func bodyFieldsOf(t reflect.Type) bodyFields {
+	if t.Kind() != reflect.Struct {
+		return bodyFields{}
+	}
+	if fields, ok := bodyFieldsCache.Load(t); ok {
+		return fields.(bodyFields)
+	}
+	fields := bodyFields{names: make(map[string]bool)}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch {
+		case name == "-" || !f.IsExported() && !f.Anonymous:
+		case name == "" && f.Anonymous && ft.Kind() == reflect.Struct:
+			embedded := bodyFieldsOf(ft)
+			maps.Copy(fields.names, embedded.names)
+			fields.queryOnly = append(fields.queryOnly, embedded.queryOnly...)
+		case name != "":
+			fields.names[name] = true
+		case f.Tag.Get("form") != "" || f.Tag.Get("uri") != "":
+			fields.queryOnly = append(fields.queryOnly, f.Name)
+		default:
+			fields.names[f.Name] = true
+		}
+	}
+	bodyFieldsCache.Store(t, fields)
+	return fields
+}
+
+// strip drops the keys of the JSON object data that encoding/json could
+// match to a field only tagged form or uri. Anything but an object is
+// returned as is.
+func (fields bodyFields) strip(data []byte) []byte {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(data, &obj) != nil {
+		return data
+	}
+	for key := range obj {
+		if fields.names[key] {
+			continue
+		}
+		for _, name := range fields.queryOnly {
+			if strings.EqualFold(key, name) {
+				delete(obj, key)
+				break
+			}
+		}
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func isJSON(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+func pathParams(c *gin.Context) map[string][]string {
+	values := make(map[string][]string, len(c.Params))
+	for _, p := range c.Params {
+		values[p.Key] = []string{p.Value}
+	}
+	return values
+}
+
+// location tells which part of the request a field came from by the tags
+// of the top-level struct field it belongs to.
+func location(t reflect.Type, structNamespace string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	parts := strings.Split(structNamespace, ".")
+	if t.Kind() != reflect.Struct || len(parts) < 2 {
+		return "body"
+	}
+	name, _, _ := strings.Cut(parts[1], "[")
+	f, ok := t.FieldByName(name)
+	switch {
+	case !ok:
+		return "body"
+	case f.Tag.Get("uri") != "":
+		return "path"
+	case f.Tag.Get("form") != "":
+		return "query"
+	}
+	return "body"
+}
+
+func hasField(errs []problem.FieldError, field string) bool {
+	for _, fe := range errs {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package bind
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Paging struct {
+	Page int `form:"page" validate:"gte=0"`
+}
+
+type updateRequest struct {
+	Paging
+	ID     string `uri:"id" validate:"required"`
+	DryRun bool   `form:"dry_run"`
+	Name   string `json:"name" validate:"required,max=5"`
+	Labels []string
+}
+
+func decode[T any](t *testing.T, target, contentType, body string, params ...gin.Param) (*T, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if body == "" {
+		c.Request.Body = http.NoBody
+	}
+	if contentType != "" {
+		c.Request.Header.Set("Content-Type", contentType)
+	}
+	c.Params = params
+	return Decode[T](c)
+}
+
+func TestDecode(t *testing.T) {
+	id := gin.Param{Key: "id", Value: "o-1"}
+
+	tests := []struct {
+		name    string
+		target  string
+		body    string
+		params  []gin.Param
+		want    updateRequest
+		wantErr []problem.FieldError
+	}{
+		{
+			name:   "all sources",
+			target: "/?page=2&dry_run=true",
+			body:   `{"name":"box","Labels":["a"]}`,
+			params: []gin.Param{id},
+			want:   updateRequest{Paging: Paging{Page: 2}, ID: "o-1", DryRun: true, Name: "box", Labels: []string{"a"}},
+		},
+		{
+			name:   "body can't set query or path fields",
+			target: "/",
+			body:   `{"name":"box","id":"o-2","ID":"o-3","dryrun":true,"DRYRUN":true,"page":7}`,
+			params: []gin.Param{id},
+			want:   updateRequest{ID: "o-1", Name: "box"},
+		},
+		{
+			name:   "untagged body fields match case-insensitively",
+			target: "/",
+			body:   `{"NAME":"box","labels":["a"]}`,
+			params: []gin.Param{id},
+			want:   updateRequest{ID: "o-1", Name: "box", Labels: []string{"a"}},
+		},
+		{
+			name:   "every invalid field is reported",
+			target: "/?page=x&dry_run=maybe",
+			body:   `{"name":"toolong"}`,
+			wantErr: []problem.FieldError{
+				{Field: "page", In: "query", Code: "type", Message: "must be an integer"},
+				{Field: "dry_run", In: "query", Code: "type", Message: "must be a boolean"},
+				{Field: "id", In: "path", Code: "required", Message: "is required"},
+				{Field: "name", In: "body", Code: "max", Message: "must have at most 5 characters"},
+			},
+		},
+		{
+			name:    "body type error",
+			target:  "/",
+			body:    `{"name":1}`,
+			params:  []gin.Param{id},
+			wantErr: []problem.FieldError{{Field: "name", In: "body", Code: "type", Message: "must be a string"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decode[updateRequest](t, tt.target, "application/json", tt.body, tt.params...)
+			if tt.wantErr != nil {
+				var p *problem.Problem
+				if !errors.As(err, &p) {
+					t.Fatalf("Decode() error = %v, want a problem", err)
+				}
+				if !reflect.DeepEqual(p.Errors, tt.wantErr) {
+					t.Fatalf("Decode() errors = %+v, want %+v", p.Errors, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("Decode() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "not JSON", contentType: "text/plain", body: `name=box`, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "malformed", contentType: "application/json", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "not an object", contentType: "application/json", body: `[1]`, wantStatus: http.StatusBadRequest},
+		{name: "JSON suffix", contentType: "application/merge-patch+json", body: `{"name":"box"}`},
+		{name: "no content type", body: `{"name":"box"}`},
+		{name: "empty body", contentType: "application/json", wantStatus: http.StatusBadRequest}, // name is required
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decode[updateRequest](t, "/", tt.contentType, tt.body, gin.Param{Key: "id", Value: "o-1"})
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				return
+			}
+			var p *problem.Problem
+			if !errors.As(err, &p) || p.Status != tt.wantStatus {
+				t.Fatalf("Decode() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDecodeTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"`+strings.Repeat("x", 64)+`"}`))
+	c.Request.Body = http.MaxBytesReader(w, c.Request.Body, 16)
+
+	_, err := Decode[updateRequest](c)
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Decode() error = %v, want *http.MaxBytesError", err)
+	}
+}
@@ -0,0 +1,114 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// message renders a validation failure in English, without the field name
+// since FieldError carries it separately.
+func message(fe validator.FieldError) string {
+	param := fe.Param()
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return "is required"
+	case "excluded_if", "excluded_unless", "excluded_with", "excluded_without":
+		return "must not be set"
+	case "len":
+		if unit != "" {
+			return "must have exactly " + param + unit
+		}
+		return "must be " + param
+	case "min":
+		if unit != "" {
+			return "must have at least " + param + unit
+		}
+		return "must be at least " + param
+	case "max":
+		if unit != "" {
+			return "must have at most " + param + unit
+		}
+		return "must be at most " + param
+	case "eq":
+		return "must be " + param
+	case "ne":
+		return "must not be " + param
+	case "gt":
+		if unit != "" {
+			return "must have more than " + param + unit
+		}
+		return "must be greater than " + param
+	case "gte":
+		if unit != "" {
+			return "must have at least " + param + unit
+		}
+		return "must be at least " + param
+	case "lt":
+		if unit != "" {
+			return "must have fewer than " + param + unit
+		}
+		return "must be less than " + param
+	case "lte":
+		if unit != "" {
+			return "must have at most " + param + unit
+		}
+		return "must be at most " + param
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "eqfield":
+		return "must equal " + param
+	case "nefield":
+		return "must differ from " + param
+	case "unique":
+		return "must not contain duplicates"
+	case "email":
+		return "must be a valid email address"
+	case "url", "http_url":
+		return "must be a valid URL"
+	case "uri":
+		return "must be a valid URI"
+	case "uuid", "uuid3", "uuid4", "uuid5":
+		return "must be a valid UUID"
+	case "alpha":
+		return "must contain only letters"
+	case "alphanum":
+		return "must contain only letters and digits"
+	case "numeric", "number":
+		return "must be numeric"
+	case "lowercase":
+		return "must be lowercase"
+	case "uppercase":
+		return "must be uppercase"
+	case "contains":
+		return fmt.Sprintf("must contain %q", param)
+	case "startswith":
+		return fmt.Sprintf("must start with %q", param)
+	case "endswith":
+		return fmt.Sprintf("must end with %q", param)
+	case "datetime":
+		return "must be a time in the layout " + param
+	case "ip", "ipv4", "ipv6":
+		return "must be a valid IP address"
+	case "hostname", "hostname_rfc1123", "fqdn":
+		return "must be a valid hostname"
+	case "iso3166_1_alpha2":
+		return "must be a two-letter country code"
+	case "iso4217":
+		return "must be a currency code"
+	}
+	if param != "" {
+		return fmt.Sprintf("failed the %s=%s check", fe.Tag(), param)
+	}
+	return "failed the " + fe.Tag() + " check"
+}
@@ -0,0 +1,62 @@
+package bodylimit
+
+import (
+	"net/http"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limiter caps request body sizes. Requests announcing a larger
+// Content-Length are rejected with 413 up front; chunked bodies are cut off
+// by http.MaxBytesReader once they exceed the limit, which handlers see as
+// an *http.MaxBytesError.
+type Limiter struct {
+	limit  int64
+	routes map[string]int64 // "METHOD /full/path" -> limit
+}
+
+type Option func(*Limiter)
+
+// WithRouteLimit overrides the limit for one route, identified by method
+// and gin full path, e.g. ("POST", "/v1/orders"). A limit of 0 or less
+// removes the cap for that route.
+func WithRouteLimit(method, path string, limit int64) Option {
+	return func(l *Limiter) {
+		l.routes[method+" "+path] = limit
+	}
+}
+
+func New(limit int64, opts ...Option) *Limiter {
+	l := &Limiter{
+		limit:  limit,
+		routes: make(map[string]int64),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func (l *Limiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := l.limit
+		if route, ok := l.routes[c.Request.Method+" "+c.FullPath()]; ok {
+			limit = route
+		}
+		if limit <= 0 || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			c.Header("Connection", "close")
+			problem.Write(c, problem.Newf(http.StatusRequestEntityTooLarge, "request body exceeds %d bytes", limit))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
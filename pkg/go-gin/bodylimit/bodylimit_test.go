@@ -0,0 +1,68 @@
+package bodylimit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(New(8, WithRouteLimit(http.MethodPost, "/upload/:id", 16), WithRouteLimit(http.MethodPost, "/import", 0)).Middleware())
+	read := func(c *gin.Context) {
+		b, err := io.ReadAll(c.Request.Body)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.String(http.StatusRequestEntityTooLarge, "read %d", len(b))
+		case err != nil:
+			c.String(http.StatusInternalServerError, err.Error())
+		default:
+			c.String(http.StatusOK, "read %d", len(b))
+		}
+	}
+	r.POST("/", read)
+	r.POST("/upload/:id", read)
+	r.POST("/import", read)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		chunked    bool
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "within the limit", path: "/", body: "12345678", wantStatus: http.StatusOK, wantBody: "read 8"},
+		{name: "Content-Length over the limit", path: "/", body: "123456789", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "chunked over the limit", path: "/", body: "123456789", chunked: true, wantStatus: http.StatusRequestEntityTooLarge, wantBody: "read 8"},
+		{name: "route limit", path: "/upload/1", body: strings.Repeat("x", 16), wantStatus: http.StatusOK, wantBody: "read 16"},
+		{name: "over the route limit", path: "/upload/1", body: strings.Repeat("x", 17), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "route without a limit", path: "/import", body: strings.Repeat("x", 64), wantStatus: http.StatusOK, wantBody: "read 64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Fatalf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+			if tt.wantBody == "" && w.Header().Get("Connection") != "close" {
+				t.Fatalf("Connection = %q on an early 413, want close", w.Header().Get("Connection"))
+			}
+		})
+	}
+}
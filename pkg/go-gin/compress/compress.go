@@ -0,0 +1,292 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DefaultMinSize = 1024
+
+var DefaultContentTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/*",
+}
+
+// Compressor compresses responses whose content type is allowed and whose
+// body reaches the minimum size, using the best coding the client accepts.
+// Responses that already carry a Content-Encoding are left alone.
+type Compressor struct {
+	minSize      int
+	level        int
+	brotli       bool
+	contentTypes []string
+	skipPaths    map[string]struct{}
+
+	codecs    map[string]*codec
+	supported []string
+}
+
+type Option func(*Compressor)
+
+func WithMinSize(n int) Option {
+	return func(c *Compressor) {
+		c.minSize = n
+	}
+}
+
+// WithLevel sets the compression level, using the gzip scale of 1 (fastest)
+// to 9 (best); brotli uses it as is on its 0-11 scale.
+func WithLevel(level int) Option {
+	return func(c *Compressor) {
+		c.level = level
+	}
+}
+
+func WithBrotli() Option {
+	return func(c *Compressor) {
+		c.brotli = true
+	}
+}
+
+// WithContentTypes sets the media types to compress. A type ending in "/*"
+// matches every subtype.
+func WithContentTypes(types ...string) Option {
+	return func(c *Compressor) {
+		c.contentTypes = types
+	}
+}
+
+func WithSkipPaths(paths ...string) Option {
+	return func(c *Compressor) {
+		for _, p := range paths {
+			c.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+func New(opts ...Option) *Compressor {
+	c := &Compressor{
+		minSize:      DefaultMinSize,
+		level:        gzip.DefaultCompression,
+		contentTypes: DefaultContentTypes,
+		skipPaths:    make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.brotli {
+		c.supported = append(c.supported, Brotli)
+	}
+	c.supported = append(c.supported, Gzip, Deflate)
+
+	c.codecs = make(map[string]*codec, len(c.supported))
+	for _, name := range c.supported {
+		c.codecs[name] = newCodec(name, c.level)
+	}
+	return c
+}
+
+func (c *Compressor) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := c.skipPaths[ctx.Request.URL.Path]; ok {
+			ctx.Next()
+			return
+		}
+
+		ctx.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		coding := negotiate(ctx.GetHeader("Accept-Encoding"), c.supported)
+		if coding == "" || ctx.Request.Method == http.MethodHead || ctx.GetHeader("Range") != "" {
+			ctx.Next()
+			return
+		}
+
+		w := &writer{
+			ResponseWriter: ctx.Writer,
+			compressor:     c,
+			codec:          c.codecs[coding],
+			status:         http.StatusOK,
+		}
+		ctx.Writer = w
+
+		completed := false
+		defer func() {
+			// On a panic, leave the response to the recovery middleware.
+			if completed {
+				w.finish()
+			} else {
+				w.release()
+			}
+			ctx.Writer = w.ResponseWriter
+		}()
+
+		ctx.Next()
+		completed = true
+	}
+}
+
+func (c *Compressor) compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range c.contentTypes {
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// writer holds back the body until it reaches the minimum size, then either
+// starts compressing or passes everything through unchanged.
+type writer struct {
+	gin.ResponseWriter
+	compressor *Compressor
+	codec      *codec
+
+	status  int
+	buf     bytes.Buffer
+	size    int
+	decided bool
+	enc     encoder
+}
+
+func (w *writer) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow is deferred to decide, as headers may still change until
+// the body size is known.
+func (w *writer) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	w.size += len(b)
+	if w.decided {
+		return w.write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.compressor.minSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *writer) write(b []byte) (int, error) {
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *writer) decide() error {
+	w.decided = true
+
+	h := w.Header()
+	if h.Get("Content-Type") == "" && w.buf.Len() > 0 {
+		h.Set("Content-Type", http.DetectContentType(w.buf.Bytes()))
+	}
+
+	if w.buf.Len() >= w.compressor.minSize &&
+		bodyAllowed(w.status) && w.status != http.StatusPartialContent &&
+		h.Get("Content-Encoding") == "" &&
+		w.compressor.compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", w.codec.name)
+		h.Del("Content-Length")
+		// The compressed bytes differ, so a strong validator no longer
+		// holds.
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		w.enc = w.codec.get(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return nil
+	}
+	_, err := w.write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// Flush commits to a decision early, so streaming responses below the
+// minimum size go out uncompressed rather than waiting.
+func (w *writer) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *writer) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+	}
+	w.release()
+}
+
+func (w *writer) release() {
+	if w.enc != nil {
+		w.codec.put(w.enc)
+		w.enc = nil
+	}
+}
+
+// Unwrap lets http.ResponseController reach the connection.
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *writer) Status() int {
+	if w.decided {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *writer) Size() int {
+	return w.size
+}
+
+func (w *writer) Written() bool {
+	return w.decided || w.buf.Len() > 0
+}
+
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,333 @@
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{Brotli, Gzip, Deflate}
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "gzip", want: Gzip},
+		{header: "gzip, deflate, br", want: Brotli},
+		{header: "GZIP;q=0.5, deflate;q=0.8", want: Deflate},
+		{header: "br;q=0, gzip", want: Gzip},
+		{header: "*", want: Brotli},
+		{header: "*;q=0.1, gzip;q=0.5", want: Gzip},
+		{header: "identity", want: ""},
+		{header: "gzip;q=0, *;q=0", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := negotiate(tt.header, supported); got != tt.want {
+				t.Fatalf("negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func decompress(t *testing.T, coding string, body []byte) string {
+	t.Helper()
+	var (
+		r   io.Reader
+		err error
+	)
+	switch coding {
+	case "":
+		return string(body)
+	case Gzip:
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case Deflate:
+		r, err = zlib.NewReader(bytes.NewReader(body))
+	case Brotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		t.Fatalf("unexpected coding %q", coding)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("decompressing %s: %v", coding, err)
+	}
+	return string(out)
+}
+
+func TestCompressor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := `{"items":"` + strings.Repeat("a", 2*DefaultMinSize) + `"}`
+	small := `{"items":"a"}`
+
+	respond := func(status int, contentType, body string, headers ...string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			for i := 0; i < len(headers); i += 2 {
+				c.Header(headers[i], headers[i+1])
+			}
+			c.Data(status, contentType, []byte(body))
+		}
+	}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		method       string
+		headers      map[string]string
+		handler      gin.HandlerFunc
+		wantStatus   int
+		wantEncoding string
+		wantBody     string
+		skipped      bool
+		wantETag     string
+	}{
+		{
+			name:         "large JSON",
+			handler:      respond(http.StatusOK, "application/json", large),
+			wantEncoding: Gzip,
+			wantBody:     large,
+		},
+		{
+			name:         "deflate",
+			headers:      map[string]string{"Accept-Encoding": "deflate"},
+			handler:      respond(http.StatusOK, "application/json", large),
+			wantEncoding: Deflate,
+			wantBody:     large,
+		},
+		{
+			name:         "brotli when enabled",
+			opts:         []Option{WithBrotli()},
+			headers:      map[string]string{"Accept-Encoding": "gzip, br"},
+			handler:      respond(http.StatusOK, "application/json", large),
+			wantEncoding: Brotli,
+			wantBody:     large,
+		},
+		{
+			name:     "brotli when not enabled",
+			headers:  map[string]string{"Accept-Encoding": "br"},
+			handler:  respond(http.StatusOK, "application/json", large),
+			wantBody: large,
+		},
+		{
+			name:     "below threshold",
+			handler:  respond(http.StatusOK, "application/json", small),
+			wantBody: small,
+		},
+		{
+			name:         "custom threshold",
+			opts:         []Option{WithMinSize(4)},
+			handler:      respond(http.StatusOK, "application/json", small),
+			wantEncoding: Gzip,
+			wantBody:     small,
+		},
+		{
+			name:     "not a compressible type",
+			handler:  respond(http.StatusOK, "image/png", large),
+			wantBody: large,
+		},
+		{
+			name:         "text wildcard",
+			handler:      respond(http.StatusOK, "text/csv; charset=utf-8", large),
+			wantEncoding: Gzip,
+			wantBody:     large,
+		},
+		{
+			name:         "sniffed content type",
+			handler:      func(c *gin.Context) { _, _ = c.Writer.WriteString("<html>" + large) },
+			wantEncoding: Gzip,
+			wantBody:     "<html>" + large,
+		},
+		{
+			name:         "already encoded",
+			handler:      respond(http.StatusOK, "application/json", large, "Content-Encoding", "zstd"),
+			wantEncoding: "zstd",
+			wantBody:     large,
+		},
+		{
+			name:     "HEAD",
+			method:   http.MethodHead,
+			handler:  respond(http.StatusOK, "application/json", large),
+			wantBody: large,
+		},
+		{
+			name:     "Range request",
+			headers:  map[string]string{"Accept-Encoding": "gzip", "Range": "bytes=0-9"},
+			handler:  respond(http.StatusOK, "application/json", large),
+			wantBody: large,
+		},
+		{
+			name:       "partial content",
+			handler:    respond(http.StatusPartialContent, "application/json", large),
+			wantStatus: http.StatusPartialContent,
+			wantBody:   large,
+		},
+		{
+			name:       "not modified",
+			handler:    func(c *gin.Context) { c.Header("ETag", `"v1"`); c.Status(http.StatusNotModified) },
+			wantStatus: http.StatusNotModified,
+			wantETag:   `"v1"`,
+		},
+		{
+			name:       "no content",
+			handler:    func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:         "error status",
+			handler:      respond(http.StatusInternalServerError, "application/problem+json", large),
+			wantStatus:   http.StatusInternalServerError,
+			wantEncoding: Gzip,
+			wantBody:     large,
+		},
+		{
+			name:         "strong ETag is weakened",
+			handler:      respond(http.StatusOK, "application/json", large, "ETag", `"v1"`),
+			wantEncoding: Gzip,
+			wantBody:     large,
+			wantETag:     `W/"v1"`,
+		},
+		{
+			name:         "weak ETag is kept",
+			handler:      respond(http.StatusOK, "application/json", large, "ETag", `W/"v1"`),
+			wantEncoding: Gzip,
+			wantBody:     large,
+			wantETag:     `W/"v1"`,
+		},
+		{
+			name:     "strong ETag of an uncompressed response is kept",
+			handler:  respond(http.StatusOK, "application/json", small, "ETag", `"v1"`),
+			wantBody: small,
+			wantETag: `"v1"`,
+		},
+		{
+			name:     "skipped path",
+			opts:     []Option{WithSkipPaths("/")},
+			skipped:  true,
+			handler:  respond(http.StatusOK, "application/json", large),
+			wantBody: large,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(New(tt.opts...).Middleware())
+			r.Handle(http.MethodGet, "/", tt.handler)
+			r.Handle(http.MethodHead, "/", tt.handler)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			wantStatus := tt.wantStatus
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			if w.Code != wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, wantStatus)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if tt.wantEncoding != "" && w.Header().Get("Content-Length") != "" {
+				t.Fatalf("Content-Length = %q on an encoded response", w.Header().Get("Content-Length"))
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Fatalf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			wantVary := "Accept-Encoding"
+			if tt.skipped {
+				wantVary = ""
+			}
+			if got := w.Header().Get("Vary"); got != wantVary {
+				t.Fatalf("Vary = %q, want %q", got, wantVary)
+			}
+			coding := tt.wantEncoding
+			if coding == "zstd" {
+				coding = ""
+			}
+			if got := decompress(t, coding, w.Body.Bytes()); got != tt.wantBody {
+				t.Fatalf("body = %.40q..., want %.40q...", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+// TestCompressorFlushThrough checks that flushed data reaches the client
+// before the handler returns, compressed or not.
+func TestCompressorFlushThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name         string
+		contentType  string
+		chunk        string
+		wantEncoding string
+	}{
+		// Events are smaller than the threshold, so the first flush
+		// sends the stream uncompressed.
+		{name: "event stream", contentType: "text/event-stream", chunk: "data: 1\n\n"},
+		{name: "large chunks", contentType: "application/x-ndjson", chunk: `{"a":"` + strings.Repeat("a", DefaultMinSize) + "\"}\n", wantEncoding: Gzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			r := gin.New()
+			r.Use(New(WithContentTypes("text/*", "application/x-ndjson")).Middleware())
+			r.GET("/", func(c *gin.Context) {
+				c.Header("Content-Type", tt.contentType)
+				c.Status(http.StatusOK)
+				_, _ = c.Writer.WriteString(tt.chunk)
+				c.Writer.Flush()
+				<-release
+			})
+			srv := httptest.NewServer(r)
+			defer srv.Close()
+			defer close(release)
+
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if got := resp.Header.Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+
+			var body io.Reader = resp.Body
+			if tt.wantEncoding == Gzip {
+				if body, err = gzip.NewReader(resp.Body); err != nil {
+					t.Fatal(err)
+				}
+			}
+			line, err := bufio.NewReader(body).ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading the flushed chunk: %v", err)
+			}
+			if want, _, _ := strings.Cut(tt.chunk, "\n"); line != want+"\n" {
+				t.Fatalf("first line = %.40q, want %.40q", line, want)
+			}
+		})
+	}
+}
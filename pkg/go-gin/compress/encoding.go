@@ -0,0 +1,108 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	Gzip    = "gzip"
+	Deflate = "deflate"
+	Brotli  = "br"
+)
+
+// encoder is a compressing writer that can be pooled.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+type codec struct {
+	name string
+	pool sync.Pool
+}
+
+func newCodec(name string, level int) *codec {
+	c := &codec{name: name}
+	c.pool.New = func() any {
+		switch name {
+		case Gzip:
+			w, err := gzip.NewWriterLevel(io.Discard, level)
+			if err != nil {
+				w = gzip.NewWriter(io.Discard)
+			}
+			return w
+		case Deflate:
+			// The "deflate" content coding is zlib-wrapped DEFLATE (RFC 9110).
+			w, err := zlib.NewWriterLevel(io.Discard, level)
+			if err != nil {
+				w = zlib.NewWriter(io.Discard)
+			}
+			return w
+		case Brotli:
+			if level < brotli.BestSpeed || level > brotli.BestCompression {
+				level = brotli.DefaultCompression
+			}
+			return brotli.NewWriterLevel(io.Discard, level)
+		}
+		return nil
+	}
+	return c
+}
+
+func (c *codec) get(w io.Writer) encoder {
+	enc := c.pool.Get().(encoder)
+	enc.Reset(w)
+	return enc
+}
+
+func (c *codec) put(enc encoder) {
+	enc.Reset(io.Discard)
+	c.pool.Put(enc)
+}
+
+// negotiate picks the coding from Accept-Encoding with the highest q-value,
+// preferring earlier entries of supported on ties. It returns "" when the
+// client wants no compression.
+func negotiate(header string, supported []string) string {
+	if header == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, name := range supported {
+		q, ok := weights[name]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,33 +24,223 @@ func WithPrefix(prefix string) Option {
 }
 
 type Config struct {
-	Server  ServerConfig
-	Metrics MetricsConfig
+	Server       ServerConfig
+	Metrics      MetricsConfig
+	OrderService OrderServiceConfig
+	OrderEvents  OrderEventsConfig
+	RateLimit    RateLimitConfig
+	Log          LogConfig
+	JWT          JWTConfig
+	APIKey       APIKeyConfig
+	CORS         CORSConfig
+	Compression  CompressionConfig
+	ETag         ETagConfig
+	OpenAPI      OpenAPIConfig
 }
 
 type ServerConfig struct {
-	Port         int
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port              int
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration // bounds slow header senders (slowloris)
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	DrainDelay        time.Duration // keep serving after the shutdown signal so endpoints can update
+	ShutdownTimeout   time.Duration // deadline for closing connections and running shutdown hooks
+	MaxHeaderBytes    int
+	MaxBodyBytes      int64 // default request body limit, 0 disables it
+	BodyLimits        []RouteBodyLimit
+	MaxConnections    int      // concurrent connections accepted, 0 is unlimited
+	TrustedProxies    []string // IPs or CIDRs whose X-Forwarded-For is believed, none by default
+	TLS               TLSConfig
 }
 
+type RouteBodyLimit struct {
+	Method string
+	Path   string // gin full path, e.g. /v1/orders/:id
+	Limit  int64
+}
+
+type TLSConfig struct {
+	CertFile     string // serving cert, empty disables TLS
+	KeyFile      string
+	CaFile       string // CA bundle for verifying client certs, system roots if empty
+	ClientAuth   string // none | request | require | verify_if_given | require_and_verify
+	MinVersion   string // 1.0 | 1.1 | 1.2 | 1.3
+	RedirectPort int    // plaintext port redirecting to HTTPS, 0 disables
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+func (t TLSConfig) GetCertFile() string { return t.CertFile }
+func (t TLSConfig) GetKeyFile() string  { return t.KeyFile }
+func (t TLSConfig) GetCaFile() string   { return t.CaFile }
+
 type MetricsConfig struct {
 	Username string
 	Password string
 }
 
+type OrderServiceConfig struct {
+	Address    string // gRPC target of OrderService, empty disables the /v1/orders routes
+	Timeout    time.Duration
+	UseTLS     bool
+	ServerName string
+	CaFile     string
+	CertFile   string // client cert, only needed when the server requires mTLS
+	KeyFile    string
+}
+
+func (c OrderServiceConfig) GetCertFile() string   { return c.CertFile }
+func (c OrderServiceConfig) GetKeyFile() string    { return c.KeyFile }
+func (c OrderServiceConfig) GetCaFile() string     { return c.CaFile }
+func (c OrderServiceConfig) GetServerName() string { return c.ServerName }
+
+type OrderEventsConfig struct {
+	Enabled      bool          // serve /v1/orders/events
+	PollInterval time.Duration // follow OrderService by polling; 0 publishes only writes made through this server
+	Heartbeat    time.Duration
+	Buffer       int // events pending per connection before it is dropped
+	History      int // events kept for Last-Event-ID resume
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Rate    float64 // requests per second per client
+	Burst   int
+	KeyBy   string // ip | subject, the authenticated API key name or JWT sub with the IP as fallback
+	Routes  []RouteRateLimit
+}
+
+type JWTConfig struct {
+	Enabled         bool
+	JWKS            string // path or http(s) URL of the JWKS document
+	RefreshInterval time.Duration
+	Issuer          string
+	Audience        string
+	Leeway          time.Duration // allowed clock skew for exp, nbf and iat
+	Algorithms      []string
+	Routes          []RouteScopes
+}
+
+type APIKeyConfig struct {
+	Enabled bool
+	File    string // JSON file of hashed keys, reloaded on change
+	Header  string
+	Routes  []RouteScopes
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string // exact origins, "*" or patterns like https://*.example.com; empty disables CORS
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration // how long browsers may cache a preflight result
+}
+
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+type CompressionConfig struct {
+	Enabled      bool
+	MinSize      int // responses below this many bytes are sent as is
+	Level        int
+	Brotli       bool
+	ContentTypes []string // media types to compress, "text/*" matches all subtypes
+}
+
+type ETagConfig struct {
+	Enabled bool
+	MaxSize int // larger responses get no ETag
+}
+
+type OpenAPIConfig struct {
+	Enabled   bool // serve /openapi.json
+	SwaggerUI bool // serve the Swagger UI under /docs
+	Title     string
+	Version   string
+}
+
+type RouteScopes struct {
+	Method string
+	Path   string // gin full path, e.g. /v1/orders/:id
+	Scopes []string
+}
+
+type LogConfig struct {
+	Format string // json | text
+	Level  string // debug | info | warn | error
+}
+
+type RouteRateLimit struct {
+	Method string
+	Path   string // gin full path, e.g. /v1/orders/:id
+	Rate   float64
+	Burst  int
+}
+
 const (
-	DefaultServerPort          = 8080
-	DefaultReadTimeoutSeconds  = 15
-	DefaultWriteTimeoutSeconds = 20
-	DefaultIdleTimeoutSeconds  = 60
+	DefaultServerPort             = 8080
+	DefaultReadTimeoutSeconds     = 15
+	DefaultWriteTimeoutSeconds    = 20
+	DefaultIdleTimeoutSeconds     = 60
+	DefaultShutdownTimeoutSeconds = 5
+
+	DefaultReadHeaderTimeoutSeconds = 5
+	DefaultMaxHeaderBytes           = 1 << 20
+	DefaultMaxBodyBytes             = 4 << 20
+
+	DefaultOrderServiceTimeoutSeconds = 5
+
+	DefaultOrderEventsHeartbeatSeconds = 15
+	DefaultOrderEventsBuffer           = 64
+	DefaultOrderEventsHistory          = 1024
+
+	DefaultTLSClientAuth = "none"
+	DefaultTLSMinVersion = "1.2"
+
+	DefaultRateLimitRate  = 10
+	DefaultRateLimitBurst = 20
+
+	DefaultJWTLeewaySeconds          = 30
+	DefaultJWTRefreshIntervalMinutes = 15
+
+	DefaultAPIKeyHeader = "X-API-Key"
+
+	DefaultCompressionMinSize = 1024
+	DefaultCompressionLevel   = 6
+	DefaultETagMaxSize        = 4 << 20
+
+	DefaultOpenAPITitle   = "Order API"
+	DefaultOpenAPIVersion = "1.0.0"
+
+	DefaultLogFormat = "json"
+	DefaultLogLevel  = "info"
 )
 
 var (
-	DefaultReadTimeout  = time.Duration(DefaultReadTimeoutSeconds) * time.Second
-	DefaultWriteTimeout = time.Duration(DefaultWriteTimeoutSeconds) * time.Second
-	DefaultIdleTimeout  = time.Duration(DefaultIdleTimeoutSeconds) * time.Second
+	DefaultReadTimeout     = time.Duration(DefaultReadTimeoutSeconds) * time.Second
+	DefaultWriteTimeout    = time.Duration(DefaultWriteTimeoutSeconds) * time.Second
+	DefaultIdleTimeout     = time.Duration(DefaultIdleTimeoutSeconds) * time.Second
+	DefaultShutdownTimeout = time.Duration(DefaultShutdownTimeoutSeconds) * time.Second
+
+	DefaultReadHeaderTimeout = time.Duration(DefaultReadHeaderTimeoutSeconds) * time.Second
+
+	DefaultOrderServiceTimeout = time.Duration(DefaultOrderServiceTimeoutSeconds) * time.Second
+
+	DefaultOrderEventsHeartbeat = time.Duration(DefaultOrderEventsHeartbeatSeconds) * time.Second
+
+	DefaultJWTLeeway          = time.Duration(DefaultJWTLeewaySeconds) * time.Second
+	DefaultJWTRefreshInterval = time.Duration(DefaultJWTRefreshIntervalMinutes) * time.Minute
+	DefaultJWTAlgorithms      = []string{"RS256", "ES256", "HS256"}
+
+	DefaultCORSMethods        = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+	DefaultCORSHeaders        = []string{"Authorization", "Content-Type", "X-Request-ID", DefaultAPIKeyHeader}
+	DefaultCORSExposedHeaders = []string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"}
+	DefaultCORSMaxAge         = 10 * time.Minute
 )
 
 func Init(opts ...Option) (*Config, error) {
@@ -70,12 +264,76 @@ func Init(opts ...Option) (*Config, error) {
 	if t := viper.GetDuration("SERVER_READ_TIMEOUT"); t > 0 {
 		cfg.Server.ReadTimeout = t
 	}
+	if t := viper.GetDuration("SERVER_READ_HEADER_TIMEOUT"); t > 0 {
+		cfg.Server.ReadHeaderTimeout = t
+	}
 	if t := viper.GetDuration("SERVER_WRITE_TIMEOUT"); t > 0 {
 		cfg.Server.WriteTimeout = t
 	}
 	if t := viper.GetDuration("SERVER_IDLE_TIMEOUT"); t > 0 {
 		cfg.Server.IdleTimeout = t
 	}
+	if t := viper.GetDuration("SERVER_DRAIN_DELAY"); t > 0 {
+		cfg.Server.DrainDelay = t
+	}
+	if t := viper.GetDuration("SERVER_SHUTDOWN_TIMEOUT"); t > 0 {
+		cfg.Server.ShutdownTimeout = t
+	}
+	if s := viper.GetString("SERVER_MAX_HEADER_BYTES"); s != "" {
+		n, err := parseByteSize(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SERVER_MAX_HEADER_BYTES: %w", err)
+		}
+		if n > 0 {
+			cfg.Server.MaxHeaderBytes = int(n)
+		}
+	}
+	if s := viper.GetString("SERVER_MAX_BODY_BYTES"); s != "" {
+		n, err := parseByteSize(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SERVER_MAX_BODY_BYTES: %w", err)
+		}
+		cfg.Server.MaxBodyBytes = n
+	}
+	if s := viper.GetString("SERVER_BODY_LIMIT_ROUTES"); s != "" {
+		limits, err := parseRouteBodyLimits(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Server.BodyLimits = limits
+	}
+	if n := viper.GetInt("SERVER_MAX_CONNECTIONS"); n > 0 {
+		cfg.Server.MaxConnections = n
+	}
+	if s := viper.GetString("SERVER_TRUSTED_PROXIES"); s != "" {
+		proxies, err := parseTrustedProxies(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Server.TrustedProxies = proxies
+	}
+
+	if s := viper.GetString("SERVER_TLS_CERT_FILE"); s != "" {
+		cfg.Server.TLS.CertFile = s
+	}
+	if s := viper.GetString("SERVER_TLS_KEY_FILE"); s != "" {
+		cfg.Server.TLS.KeyFile = s
+	}
+	if s := viper.GetString("SERVER_TLS_CA_FILE"); s != "" {
+		cfg.Server.TLS.CaFile = s
+	}
+	if s := viper.GetString("SERVER_TLS_CLIENT_AUTH"); s != "" {
+		cfg.Server.TLS.ClientAuth = s
+	}
+	if s := viper.GetString("SERVER_TLS_MIN_VERSION"); s != "" {
+		cfg.Server.TLS.MinVersion = s
+	}
+	if port := viper.GetInt("SERVER_TLS_REDIRECT_PORT"); port >= 1 && port <= 65535 {
+		cfg.Server.TLS.RedirectPort = port
+	}
+	if (cfg.Server.TLS.CertFile == "") != (cfg.Server.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE must be set together")
+	}
 
 	if u := viper.GetString("METRICS_USERNAME"); u != "" {
 		cfg.Metrics.Username = u
@@ -84,20 +342,384 @@ func Init(opts ...Option) (*Config, error) {
 		cfg.Metrics.Password = p
 	}
 
+	if a := viper.GetString("ORDER_SERVICE_ADDRESS"); a != "" {
+		cfg.OrderService.Address = a
+	}
+	if t := viper.GetDuration("ORDER_SERVICE_TIMEOUT"); t > 0 {
+		cfg.OrderService.Timeout = t
+	}
+	cfg.OrderService.UseTLS = viper.GetBool("ORDER_SERVICE_USE_TLS")
+	if s := viper.GetString("ORDER_SERVICE_SERVER_NAME"); s != "" {
+		cfg.OrderService.ServerName = s
+	}
+	if s := viper.GetString("ORDER_SERVICE_CA_FILE"); s != "" {
+		cfg.OrderService.CaFile = s
+	}
+	if s := viper.GetString("ORDER_SERVICE_CERT_FILE"); s != "" {
+		cfg.OrderService.CertFile = s
+	}
+	if s := viper.GetString("ORDER_SERVICE_KEY_FILE"); s != "" {
+		cfg.OrderService.KeyFile = s
+	}
+
+	cfg.OrderEvents.Enabled = viper.GetBool("ORDER_EVENTS_ENABLED")
+	if t := viper.GetDuration("ORDER_EVENTS_POLL_INTERVAL"); t > 0 {
+		cfg.OrderEvents.PollInterval = t
+	}
+	if t := viper.GetDuration("ORDER_EVENTS_HEARTBEAT"); t > 0 {
+		cfg.OrderEvents.Heartbeat = t
+	}
+	if n := viper.GetInt("ORDER_EVENTS_BUFFER"); n > 0 {
+		cfg.OrderEvents.Buffer = n
+	}
+	if n := viper.GetInt("ORDER_EVENTS_HISTORY"); n > 0 {
+		cfg.OrderEvents.History = n
+	}
+
+	cfg.RateLimit.Enabled = viper.GetBool("RATE_LIMIT_ENABLED")
+	if r := viper.GetFloat64("RATE_LIMIT_RATE"); r > 0 {
+		cfg.RateLimit.Rate = r
+	}
+	if b := viper.GetInt("RATE_LIMIT_BURST"); b > 0 {
+		cfg.RateLimit.Burst = b
+	}
+	if k := viper.GetString("RATE_LIMIT_KEY_BY"); k != "" {
+		switch k {
+		case "ip", "subject":
+			cfg.RateLimit.KeyBy = k
+		default:
+			return nil, fmt.Errorf("invalid RATE_LIMIT_KEY_BY %q, want ip or subject", k)
+		}
+	}
+	if r := viper.GetString("RATE_LIMIT_ROUTES"); r != "" {
+		routes, err := parseRouteRateLimits(r)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RateLimit.Routes = routes
+	}
+
+	cfg.JWT.Enabled = viper.GetBool("JWT_ENABLED")
+	if s := viper.GetString("JWT_JWKS"); s != "" {
+		cfg.JWT.JWKS = s
+	}
+	if t := viper.GetDuration("JWT_JWKS_REFRESH_INTERVAL"); t > 0 {
+		cfg.JWT.RefreshInterval = t
+	}
+	if s := viper.GetString("JWT_ISSUER"); s != "" {
+		cfg.JWT.Issuer = s
+	}
+	if s := viper.GetString("JWT_AUDIENCE"); s != "" {
+		cfg.JWT.Audience = s
+	}
+	if t := viper.GetDuration("JWT_LEEWAY"); t > 0 {
+		cfg.JWT.Leeway = t
+	}
+	if s := viper.GetString("JWT_ALGORITHMS"); s != "" {
+		cfg.JWT.Algorithms = splitList(s)
+	}
+	if s := viper.GetString("JWT_ROUTE_SCOPES"); s != "" {
+		routes, err := parseRouteScopes(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.JWT.Routes = routes
+	}
+	if cfg.JWT.Enabled && cfg.JWT.JWKS == "" {
+		return nil, fmt.Errorf("JWT_JWKS is required when JWT_ENABLED is set")
+	}
+
+	cfg.APIKey.Enabled = viper.GetBool("API_KEY_ENABLED")
+	if s := viper.GetString("API_KEY_FILE"); s != "" {
+		cfg.APIKey.File = s
+	}
+	if s := viper.GetString("API_KEY_HEADER"); s != "" {
+		cfg.APIKey.Header = s
+	}
+	if s := viper.GetString("API_KEY_ROUTE_SCOPES"); s != "" {
+		routes, err := parseRouteScopes(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.APIKey.Routes = routes
+	}
+	if cfg.APIKey.Enabled && cfg.APIKey.File == "" {
+		return nil, fmt.Errorf("API_KEY_FILE is required when API_KEY_ENABLED is set")
+	}
+
+	if s := viper.GetString("CORS_ALLOWED_ORIGINS"); s != "" {
+		cfg.CORS.AllowedOrigins = splitList(s)
+	}
+	if s := viper.GetString("CORS_ALLOWED_METHODS"); s != "" {
+		cfg.CORS.AllowedMethods = splitList(s)
+	}
+	if s := viper.GetString("CORS_ALLOWED_HEADERS"); s != "" {
+		cfg.CORS.AllowedHeaders = splitList(s)
+	}
+	if s := viper.GetString("CORS_EXPOSED_HEADERS"); s != "" {
+		cfg.CORS.ExposedHeaders = splitList(s)
+	}
+	cfg.CORS.AllowCredentials = viper.GetBool("CORS_ALLOW_CREDENTIALS")
+	if t := viper.GetDuration("CORS_MAX_AGE"); t > 0 {
+		cfg.CORS.MaxAge = t
+	}
+
+	cfg.Compression.Enabled = viper.GetBool("COMPRESSION_ENABLED")
+	if s := viper.GetString("COMPRESSION_MIN_SIZE"); s != "" {
+		n, err := parseByteSize(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid COMPRESSION_MIN_SIZE: %w", err)
+		}
+		if n > 0 {
+			cfg.Compression.MinSize = int(n)
+		}
+	}
+	if n := viper.GetInt("COMPRESSION_LEVEL"); n > 0 {
+		cfg.Compression.Level = n
+	}
+	cfg.Compression.Brotli = viper.GetBool("COMPRESSION_BROTLI")
+	if s := viper.GetString("COMPRESSION_CONTENT_TYPES"); s != "" {
+		cfg.Compression.ContentTypes = splitList(s)
+	}
+
+	cfg.ETag.Enabled = viper.GetBool("ETAG_ENABLED")
+	if s := viper.GetString("ETAG_MAX_SIZE"); s != "" {
+		n, err := parseByteSize(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ETAG_MAX_SIZE: %w", err)
+		}
+		if n > 0 {
+			cfg.ETag.MaxSize = int(n)
+		}
+	}
+
+	if viper.IsSet("OPENAPI_ENABLED") {
+		cfg.OpenAPI.Enabled = viper.GetBool("OPENAPI_ENABLED")
+	}
+	cfg.OpenAPI.SwaggerUI = viper.GetBool("OPENAPI_SWAGGER_UI")
+	if s := viper.GetString("OPENAPI_TITLE"); s != "" {
+		cfg.OpenAPI.Title = s
+	}
+	if s := viper.GetString("OPENAPI_VERSION"); s != "" {
+		cfg.OpenAPI.Version = s
+	}
+
+	if f := viper.GetString("LOG_FORMAT"); f != "" {
+		cfg.Log.Format = f
+	}
+	if l := viper.GetString("LOG_LEVEL"); l != "" {
+		cfg.Log.Level = l
+	}
+
 	return cfg, nil
 }
 
+// parseRouteRateLimits parses "METHOD /path=rate:burst" entries separated by
+// commas, e.g. "POST /v1/orders=1:5,GET /v1/orders/:id=20:40".
+func parseRouteRateLimits(s string) ([]RouteRateLimit, error) {
+	var routes []RouteRateLimit
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, limit, ok := strings.Cut(entry, "=")
+		method, path, ok2 := strings.Cut(strings.TrimSpace(route), " ")
+		rate, burst, ok3 := strings.Cut(limit, ":")
+		if !ok || !ok2 || !ok3 {
+			return nil, fmt.Errorf("invalid rate limit route %q, want \"METHOD /path=rate:burst\"", entry)
+		}
+
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("invalid rate in rate limit route %q", entry)
+		}
+		b, err := strconv.Atoi(burst)
+		if err != nil || b < 1 {
+			return nil, fmt.Errorf("invalid burst in rate limit route %q", entry)
+		}
+
+		routes = append(routes, RouteRateLimit{
+			Method: strings.ToUpper(method),
+			Path:   strings.TrimSpace(path),
+			Rate:   r,
+			Burst:  b,
+		})
+	}
+	return routes, nil
+}
+
+// parseRouteBodyLimits parses "METHOD /path=size" entries separated by
+// commas, where size is as for parseByteSize, e.g.
+// "POST /v1/orders=64kb,PATCH /v1/orders/:id=16kb".
+func parseRouteBodyLimits(s string) ([]RouteBodyLimit, error) {
+	var limits []RouteBodyLimit
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, size, ok := strings.Cut(entry, "=")
+		method, path, ok2 := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("invalid body limit route %q, want \"METHOD /path=size\"", entry)
+		}
+		n, err := parseByteSize(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size in body limit route %q: %w", entry, err)
+		}
+
+		limits = append(limits, RouteBodyLimit{
+			Method: strings.ToUpper(method),
+			Path:   strings.TrimSpace(path),
+			Limit:  n,
+		})
+	}
+	return limits, nil
+}
+
+// parseByteSize parses a byte count with an optional k, m or g suffix, each
+// optionally followed by b, in any case: "512", "64kb", "1M". It is used for
+// every size setting so they all take the same syntax, and unlike
+// viper.GetSizeInBytes it reports malformed sizes instead of reading them as
+// 0.
+func parseByteSize(s string) (int64, error) {
+	num := strings.ToLower(strings.TrimSpace(s))
+	num = strings.TrimSuffix(num, "b")
+	shift := 0
+	if len(num) > 0 {
+		switch num[len(num)-1] {
+		case 'k':
+			shift = 10
+		case 'm':
+			shift = 20
+		case 'g':
+			shift = 30
+		}
+	}
+	if shift > 0 {
+		num = strings.TrimSpace(num[:len(num)-1])
+	}
+
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64>>shift {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n << shift, nil
+}
+
+// parseRouteScopes parses "METHOD /path=scope scope" entries separated by
+// commas, e.g. "POST /v1/orders=orders:write,DELETE /v1/orders/:id=orders:write orders:admin".
+func parseRouteScopes(s string) ([]RouteScopes, error) {
+	var routes []RouteScopes
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, scopes, ok := strings.Cut(entry, "=")
+		method, path, ok2 := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok || !ok2 || len(strings.Fields(scopes)) == 0 {
+			return nil, fmt.Errorf("invalid route scopes %q, want \"METHOD /path=scope scope\"", entry)
+		}
+
+		routes = append(routes, RouteScopes{
+			Method: strings.ToUpper(method),
+			Path:   strings.TrimSpace(path),
+			Scopes: strings.Fields(scopes),
+		})
+	}
+	return routes, nil
+}
+
+// parseTrustedProxies parses a comma separated list of IPs and CIDRs.
+func parseTrustedProxies(s string) ([]string, error) {
+	proxies := splitList(s)
+	for _, p := range proxies {
+		if _, err := netip.ParsePrefix(p); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(p); err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q, want an IP or CIDR", p)
+		}
+	}
+	return proxies, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func defaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:         DefaultServerPort,
-			ReadTimeout:  DefaultReadTimeout,
-			WriteTimeout: DefaultWriteTimeout,
-			IdleTimeout:  DefaultIdleTimeout,
+			Port:              DefaultServerPort,
+			ReadTimeout:       DefaultReadTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+			ShutdownTimeout:   DefaultShutdownTimeout,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			MaxHeaderBytes:    DefaultMaxHeaderBytes,
+			MaxBodyBytes:      DefaultMaxBodyBytes,
+			TLS: TLSConfig{
+				ClientAuth: DefaultTLSClientAuth,
+				MinVersion: DefaultTLSMinVersion,
+			},
 		},
 		Metrics: MetricsConfig{
 			Username: "",
 			Password: "",
 		},
+		OrderService: OrderServiceConfig{
+			Timeout: DefaultOrderServiceTimeout,
+		},
+		OrderEvents: OrderEventsConfig{
+			Heartbeat: DefaultOrderEventsHeartbeat,
+			Buffer:    DefaultOrderEventsBuffer,
+			History:   DefaultOrderEventsHistory,
+		},
+		RateLimit: RateLimitConfig{
+			Rate:  DefaultRateLimitRate,
+			Burst: DefaultRateLimitBurst,
+		},
+		JWT: JWTConfig{
+			RefreshInterval: DefaultJWTRefreshInterval,
+			Leeway:          DefaultJWTLeeway,
+			Algorithms:      DefaultJWTAlgorithms,
+		},
+		APIKey: APIKeyConfig{
+			Header: DefaultAPIKeyHeader,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: DefaultCORSMethods,
+			AllowedHeaders: DefaultCORSHeaders,
+			ExposedHeaders: DefaultCORSExposedHeaders,
+			MaxAge:         DefaultCORSMaxAge,
+		},
+		Compression: CompressionConfig{
+			MinSize: DefaultCompressionMinSize,
+			Level:   DefaultCompressionLevel,
+		},
+		ETag: ETagConfig{
+			MaxSize: DefaultETagMaxSize,
+		},
+		OpenAPI: OpenAPIConfig{
+			Enabled: true,
+			Title:   DefaultOpenAPITitle,
+			Version: DefaultOpenAPIVersion,
+		},
+		Log: LogConfig{
+			Format: DefaultLogFormat,
+			Level:  DefaultLogLevel,
+		},
 	}
 }
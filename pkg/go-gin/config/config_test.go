@@ -0,0 +1,102 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "10.0.0.1", want: []string{"10.0.0.1"}},
+		{in: "10.0.0.0/8, 192.168.1.1 ,::1", want: []string{"10.0.0.0/8", "192.168.1.1", "::1"}},
+		{in: "fd00::/8", want: []string{"fd00::/8"}},
+		{in: "proxy.internal", wantErr: true},
+		{in: "10.0.0.0/33", wantErr: true},
+		{in: "10.0.0.1,*", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTrustedProxies(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTrustedProxies(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseTrustedProxies(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "512", want: 512},
+		{in: "512b", want: 512},
+		{in: "64k", want: 64 << 10},
+		{in: "64kb", want: 64 << 10},
+		{in: " 64 KB ", want: 64 << 10},
+		{in: "4M", want: 4 << 20},
+		{in: "1gb", want: 1 << 30},
+		{in: "", wantErr: true},
+		{in: "kb", wantErr: true},
+		{in: "-1", wantErr: true},
+		{in: "1.5mb", wantErr: true},
+		{in: "10tb", wantErr: true},
+		{in: "64 kilobytes", wantErr: true},
+		{in: "9223372036854775807g", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseByteSize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseByteSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("parseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitSizeSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantMinSize int
+		wantMaxSize int
+		wantBody    int64
+		wantErr     bool
+	}{
+		{"defaults", nil, DefaultCompressionMinSize, DefaultETagMaxSize, DefaultMaxBodyBytes, false},
+		{"plain bytes", map[string]string{"COMPRESSION_MIN_SIZE": "512", "ETAG_MAX_SIZE": "2048"}, 512, 2048, DefaultMaxBodyBytes, false},
+		{"suffixed", map[string]string{"COMPRESSION_MIN_SIZE": "2kb", "ETAG_MAX_SIZE": "8M", "SERVER_MAX_BODY_BYTES": "512KB"}, 2 << 10, 8 << 20, 512 << 10, false},
+		{"bad compression size", map[string]string{"COMPRESSION_MIN_SIZE": "2 kilobytes"}, 0, 0, 0, true},
+		{"bad ETag size", map[string]string{"ETAG_MAX_SIZE": "-1"}, 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := Init()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.Compression.MinSize != tt.wantMinSize || cfg.ETag.MaxSize != tt.wantMaxSize || cfg.Server.MaxBodyBytes != tt.wantBody {
+				t.Fatalf("compression min %d, ETag max %d, body %d; want %d, %d, %d",
+					cfg.Compression.MinSize, cfg.ETag.MaxSize, cfg.Server.MaxBodyBytes, tt.wantMinSize, tt.wantMaxSize, tt.wantBody)
+			}
+		})
+	}
+}
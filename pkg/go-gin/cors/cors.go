@@ -0,0 +1,251 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	DefaultMethods = []string{
+		http.MethodGet, http.MethodHead, http.MethodPost,
+		http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}
+	DefaultHeaders = []string{"Authorization", "Content-Type", "X-Request-ID"}
+)
+
+const DefaultMaxAge = 10 * time.Minute
+
+// CORS answers preflight requests and adds CORS headers to responses for
+// allowed origins. Requests from other origins are served without them,
+// which makes browsers block the response.
+type CORS struct {
+	anyOrigin   bool
+	origins     map[string]struct{}
+	patterns    []pattern
+	methods     []string
+	headers     []string
+	anyHeader   bool
+	exposed     []string
+	credentials bool
+	maxAge      time.Duration
+}
+
+type Option func(*CORS) error
+
+// WithOrigins allows origins given exactly, "*" for any origin, or with a
+// single wildcard such as "https://*.example.com", which matches any
+// subdomain but not example.com itself.
+func WithOrigins(origins ...string) Option {
+	return func(c *CORS) error {
+		for _, o := range origins {
+			o = strings.ToLower(strings.TrimSpace(o))
+			switch n := strings.Count(o, "*"); {
+			case o == "*":
+				c.anyOrigin = true
+			case n == 0:
+				c.origins[o] = struct{}{}
+			case n == 1:
+				prefix, suffix, _ := strings.Cut(o, "*")
+				c.patterns = append(c.patterns, pattern{prefix: prefix, suffix: suffix})
+			default:
+				return fmt.Errorf("invalid CORS origin %q: at most one wildcard is allowed", o)
+			}
+		}
+		return nil
+	}
+}
+
+func WithMethods(methods ...string) Option {
+	return func(c *CORS) error {
+		c.methods = nil
+		for _, m := range methods {
+			c.methods = append(c.methods, strings.ToUpper(strings.TrimSpace(m)))
+		}
+		return nil
+	}
+}
+
+// WithHeaders sets the request headers browsers may send; "*" allows
+// whatever a preflight asks for.
+func WithHeaders(headers ...string) Option {
+	return func(c *CORS) error {
+		c.headers = nil
+		for _, h := range headers {
+			if h == "*" {
+				c.anyHeader = true
+				continue
+			}
+			c.headers = append(c.headers, http.CanonicalHeaderKey(strings.TrimSpace(h)))
+		}
+		return nil
+	}
+}
+
+// WithExposedHeaders lets scripts read response headers beyond the
+// CORS-safelisted ones.
+func WithExposedHeaders(headers ...string) Option {
+	return func(c *CORS) error {
+		c.exposed = headers
+		return nil
+	}
+}
+
+func WithCredentials() Option {
+	return func(c *CORS) error {
+		c.credentials = true
+		return nil
+	}
+}
+
+func WithMaxAge(d time.Duration) Option {
+	return func(c *CORS) error {
+		c.maxAge = d
+		return nil
+	}
+}
+
+func New(opts ...Option) (*CORS, error) {
+	c := &CORS{
+		origins: make(map[string]struct{}),
+		methods: DefaultMethods,
+		headers: DefaultHeaders,
+		maxAge:  DefaultMaxAge,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.anyOrigin && c.credentials {
+		return nil, fmt.Errorf("CORS origin \"*\" cannot be combined with credentials")
+	}
+	return c, nil
+}
+
+type pattern struct {
+	prefix string
+	suffix string
+}
+
+func (p pattern) match(origin string) bool {
+	return len(origin) > len(p.prefix)+len(p.suffix) &&
+		strings.HasPrefix(origin, p.prefix) &&
+		strings.HasSuffix(origin, p.suffix)
+}
+
+func (c *CORS) allowed(origin string) bool {
+	if c.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := c.origins[origin]; ok {
+		return true
+	}
+	for _, p := range c.patterns {
+		if p.match(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) Middleware() gin.HandlerFunc {
+	methods := strings.Join(c.methods, ", ")
+	headers := strings.Join(c.headers, ", ")
+	exposed := strings.Join(c.exposed, ", ")
+	maxAge := strconv.Itoa(int(c.maxAge.Seconds()))
+
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		preflight := ctx.Request.Method == http.MethodOptions &&
+			ctx.GetHeader("Access-Control-Request-Method") != ""
+
+		h := ctx.Writer.Header()
+		if !c.anyOrigin {
+			h.Add("Vary", "Origin")
+		}
+
+		if origin == "" {
+			ctx.Next()
+			return
+		}
+
+		if !c.allowed(origin) {
+			if preflight {
+				problem.Write(ctx, problem.Forbidden("origin not allowed by CORS policy"))
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		if c.anyOrigin {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.credentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
+			}
+			ctx.Next()
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+
+		if !c.methodAllowed(ctx.GetHeader("Access-Control-Request-Method")) {
+			problem.Write(ctx, problem.Forbidden("method not allowed by CORS policy"))
+			return
+		}
+		requested := ctx.GetHeader("Access-Control-Request-Headers")
+		if !c.headersAllowed(requested) {
+			problem.Write(ctx, problem.Forbidden("headers not allowed by CORS policy"))
+			return
+		}
+
+		h.Set("Access-Control-Allow-Methods", methods)
+		if c.anyHeader {
+			if requested != "" {
+				h.Set("Access-Control-Allow-Headers", requested)
+			}
+		} else if headers != "" {
+			h.Set("Access-Control-Allow-Headers", headers)
+		}
+		if c.maxAge > 0 {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func (c *CORS) methodAllowed(method string) bool {
+	return slices.Contains(c.methods, strings.ToUpper(method))
+}
+
+func (c *CORS) headersAllowed(requested string) bool {
+	if c.anyHeader || requested == "" {
+		return true
+	}
+	for _, h := range strings.Split(requested, ",") {
+		h = http.CanonicalHeaderKey(strings.TrimSpace(h))
+		if h != "" && !slices.Contains(c.headers, h) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(WithOrigins("https://*.*.example.com")); err == nil {
+		t.Fatal("New() accepted two wildcards")
+	}
+	if _, err := New(WithOrigins("*"), WithCredentials()); err == nil {
+		t.Fatal(`New() accepted "*" with credentials`)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		method      string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "no origin",
+			opts:        []Option{WithOrigins("https://app.example.com")},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+		},
+		{
+			name:        "allowed origin",
+			opts:        []Option{WithOrigins("https://app.example.com"), WithExposedHeaders("X-Request-ID")},
+			headers:     map[string]string{"Origin": "https://APP.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://APP.example.com", "Access-Control-Expose-Headers": "X-Request-ID"},
+		},
+		{
+			name:        "other origin",
+			opts:        []Option{WithOrigins("https://app.example.com")},
+			headers:     map[string]string{"Origin": "https://evil.test"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "wildcard subdomain",
+			opts:        []Option{WithOrigins("https://*.example.com")},
+			headers:     map[string]string{"Origin": "https://a.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://a.example.com"},
+		},
+		{
+			name:        "wildcard doesn't match the apex",
+			opts:        []Option{WithOrigins("https://*.example.com")},
+			headers:     map[string]string{"Origin": "https://example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "any origin",
+			opts:        []Option{WithOrigins("*")},
+			headers:     map[string]string{"Origin": "https://a.test"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "*", "Vary": ""},
+		},
+		{
+			name:        "credentials",
+			opts:        []Option{WithOrigins("https://a.test"), WithCredentials()},
+			headers:     map[string]string{"Origin": "https://a.test"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Credentials": "true"},
+		},
+		{
+			name:    "preflight",
+			opts:    []Option{WithOrigins("https://a.test"), WithMethods("get", "post")},
+			method:  http.MethodOptions,
+			headers: map[string]string{"Origin": "https://a.test", "Access-Control-Request-Method": "POST", "Access-Control-Request-Headers": "content-type"},
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://a.test",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Authorization, Content-Type, X-Request-ID",
+				"Access-Control-Max-Age":       "600",
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "preflight echoes requested headers for *",
+			opts:        []Option{WithOrigins("https://a.test"), WithHeaders("*"), WithMaxAge(0)},
+			method:      http.MethodOptions,
+			headers:     map[string]string{"Origin": "https://a.test", "Access-Control-Request-Method": "GET", "Access-Control-Request-Headers": "x-custom"},
+			wantStatus:  http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Headers": "x-custom", "Access-Control-Max-Age": ""},
+		},
+		{
+			name:       "preflight from another origin",
+			opts:       []Option{WithOrigins("https://a.test")},
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://b.test", "Access-Control-Request-Method": "GET"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "preflight for a method not allowed",
+			opts:       []Option{WithOrigins("https://a.test"), WithMethods("GET")},
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://a.test", "Access-Control-Request-Method": "DELETE"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "preflight for a header not allowed",
+			opts:       []Option{WithOrigins("https://a.test")},
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://a.test", "Access-Control-Request-Method": "GET", "Access-Control-Request-Headers": "X-Secret"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			// Without Access-Control-Request-Method it's an ordinary
+			// OPTIONS request for the handler.
+			name:       "plain OPTIONS",
+			opts:       []Option{WithOrigins("https://a.test")},
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://a.test"},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := gin.New()
+			r.Use(c.Middleware())
+			r.Any("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for k, want := range tt.wantHeaders {
+				if got := w.Header().Get(k); got != want {
+					t.Fatalf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package etag
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxSize bounds the body buffered for hashing; larger responses are
+// streamed without an ETag.
+const DefaultMaxSize = 4 << 20
+
+type ETag struct {
+	maxSize   int
+	skipPaths map[string]struct{}
+}
+
+type Option func(*ETag)
+
+func WithMaxSize(n int) Option {
+	return func(e *ETag) {
+		e.maxSize = n
+	}
+}
+
+func WithSkipPaths(paths ...string) Option {
+	return func(e *ETag) {
+		for _, p := range paths {
+			e.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+func New(opts ...Option) *ETag {
+	e := &ETag{
+		maxSize:   DefaultMaxSize,
+		skipPaths: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Middleware adds a weak ETag derived from the body to successful GET and
+// HEAD responses that don't set their own, and answers 304 Not Modified
+// when it matches If-None-Match.
+func (e *ETag) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+		if _, ok := e.skipPaths[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+
+		w := &writer{ResponseWriter: c.Writer, maxSize: e.maxSize, status: http.StatusOK}
+		c.Writer = w
+
+		completed := false
+		defer func() {
+			c.Writer = w.ResponseWriter
+			if completed {
+				w.finish(c.GetHeader("If-None-Match"))
+			}
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
+
+// writer buffers the body so it can be hashed before anything is sent.
+// Flushing or outgrowing the buffer switches it to pass-through.
+type writer struct {
+	gin.ResponseWriter
+	maxSize int
+
+	status      int
+	buf         bytes.Buffer
+	size        int
+	passthrough bool
+}
+
+func (w *writer) WriteHeader(code int) {
+	if !w.passthrough {
+		w.status = code
+	}
+}
+
+func (w *writer) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	w.size += len(b)
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.buf.Len()+len(b) > w.maxSize {
+		if err := w.startPassthrough(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *writer) Flush() {
+	if !w.passthrough {
+		_ = w.startPassthrough()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *writer) startPassthrough() error {
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *writer) finish(ifNoneMatch string) {
+	if w.passthrough {
+		return
+	}
+
+	h := w.Header()
+	if w.status == http.StatusOK && h.Get("ETag") == "" {
+		sum := sha256.Sum256(w.buf.Bytes())
+		h.Set("ETag", `W/"`+base64.RawURLEncoding.EncodeToString(sum[:16])+`"`)
+	}
+
+	if w.status == http.StatusOK && ifNoneMatch != "" && matches(ifNoneMatch, h.Get("ETag")) {
+		h.Del("Content-Length")
+		h.Del("Content-Type")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
+
+// Unwrap lets http.ResponseController reach the connection.
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *writer) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *writer) Size() int {
+	return w.size
+}
+
+func (w *writer) Written() bool {
+	return w.passthrough || w.buf.Len() > 0
+}
+
+// matches applies the weak comparison of RFC 9110 section 13.1.2 to each
+// entity tag in an If-None-Match list.
+func matches(header, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
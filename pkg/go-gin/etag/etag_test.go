@@ -0,0 +1,150 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/go-gin/compress"
+
+	"github.com/gin-gonic/gin"
+)
+
+const body = `{"id":"o-1"}`
+
+func newRouter(handler gin.HandlerFunc, mw ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(mw...)
+	r.Handle(http.MethodGet, "/", handler)
+	r.Handle(http.MethodHead, "/", handler)
+	r.Handle(http.MethodPost, "/", handler)
+	return r
+}
+
+func do(r http.Handler, method string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", nil)
+	for i := 0; i < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	ok := func(c *gin.Context) { c.Data(http.StatusOK, "application/json", []byte(body)) }
+	tag := do(newRouter(ok, New().Middleware()), http.MethodGet).Header().Get("ETag")
+	if !strings.HasPrefix(tag, `W/"`) {
+		t.Fatalf("ETag = %q, want a weak tag", tag)
+	}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		method     string
+		handler    gin.HandlerFunc
+		headers    []string
+		wantStatus int
+		wantETag   string
+		wantBody   string
+	}{
+		{name: "GET", handler: ok, wantStatus: http.StatusOK, wantETag: tag, wantBody: body},
+		// The recorder keeps what the handler writes; net/http drops it.
+		{name: "HEAD", method: http.MethodHead, handler: ok, wantStatus: http.StatusOK, wantETag: tag, wantBody: body},
+		{name: "POST", method: http.MethodPost, handler: ok, wantStatus: http.StatusOK, wantBody: body},
+		{
+			name:       "error status",
+			handler:    func(c *gin.Context) { c.Data(http.StatusNotFound, "application/json", []byte(body)) },
+			wantStatus: http.StatusNotFound,
+			wantBody:   body,
+		},
+		{
+			name: "handler's own ETag",
+			handler: func(c *gin.Context) {
+				c.Header("ETag", `"v1"`)
+				ok(c)
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"v1"`,
+			wantBody:   body,
+		},
+		{name: "If-None-Match", handler: ok, headers: []string{"If-None-Match", tag}, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "If-None-Match list", handler: ok, headers: []string{"If-None-Match", `"other", ` + tag}, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "If-None-Match wildcard", handler: ok, headers: []string{"If-None-Match", "*"}, wantStatus: http.StatusNotModified, wantETag: tag},
+		{name: "If-None-Match stale", handler: ok, headers: []string{"If-None-Match", `W/"other"`}, wantStatus: http.StatusOK, wantETag: tag, wantBody: body},
+		{
+			name: "weak comparison with a strong tag",
+			handler: func(c *gin.Context) {
+				c.Header("ETag", `"v1"`)
+				ok(c)
+			},
+			headers:    []string{"If-None-Match", `W/"v1"`},
+			wantStatus: http.StatusNotModified,
+			wantETag:   `"v1"`,
+		},
+		{name: "larger than the buffer", opts: []Option{WithMaxSize(4)}, handler: ok, wantStatus: http.StatusOK, wantBody: body},
+		{
+			name: "flushed",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusOK)
+				_, _ = c.Writer.WriteString(body)
+				c.Writer.Flush()
+			},
+			headers:    []string{"If-None-Match", "*"},
+			wantStatus: http.StatusOK,
+			wantBody:   body,
+		},
+		{name: "skipped path", opts: []Option{WithSkipPaths("/")}, handler: ok, wantStatus: http.StatusOK, wantBody: body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := do(newRouter(tt.handler, New(tt.opts...).Middleware()), method, tt.headers...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Fatalf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Fatalf("body = %q, want %q", got, tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Header().Get("Content-Type") != "" {
+				t.Fatalf("Content-Type = %q on a 304", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// TestUnderCompression runs the middleware inside compress, as the server
+// does: the tag must not depend on the coding, and a 304 carries no body.
+func TestUnderCompression(t *testing.T) {
+	large := `{"items":"` + strings.Repeat("a", 2*compress.DefaultMinSize) + `"}`
+	r := newRouter(
+		func(c *gin.Context) { c.Data(http.StatusOK, "application/json", []byte(large)) },
+		compress.New().Middleware(), New().Middleware(),
+	)
+
+	plain := do(r, http.MethodGet)
+	gzipped := do(r, http.MethodGet, "Accept-Encoding", "gzip")
+	if gzipped.Header().Get("Content-Encoding") != compress.Gzip {
+		t.Fatalf("Content-Encoding = %q, want gzip", gzipped.Header().Get("Content-Encoding"))
+	}
+	tag := gzipped.Header().Get("ETag")
+	if tag == "" || tag != plain.Header().Get("ETag") {
+		t.Fatalf("ETag = %q with gzip and %q without, want the same tag", tag, plain.Header().Get("ETag"))
+	}
+
+	w := do(r, http.MethodGet, "Accept-Encoding", "gzip", "If-None-Match", tag)
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("status = %d, want 304", w.Code)
+	}
+	if w.Body.Len() != 0 || w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("304 has %d body bytes and Content-Encoding %q", w.Body.Len(), w.Header().Get("Content-Encoding"))
+	}
+}
@@ -0,0 +1,181 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultTimeout  = 2 * time.Second
+	DefaultCacheTTL = 5 * time.Second
+)
+
+// Checker reports whether a dependency is usable. Check should honour ctx,
+// which carries the per-check timeout.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c checkerFunc) Name() string                    { return c.name }
+func (c checkerFunc) Check(ctx context.Context) error { return c.fn(ctx) }
+
+func NewChecker(name string, fn func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, fn: fn}
+}
+
+type CheckOption func(*check)
+
+func WithTimeout(d time.Duration) CheckOption {
+	return func(c *check) {
+		c.timeout = d
+	}
+}
+
+// WithCacheTTL reuses a result for d so frequent probes don't hammer the
+// dependency. Zero disables caching.
+func WithCacheTTL(d time.Duration) CheckOption {
+	return func(c *check) {
+		c.ttl = d
+	}
+}
+
+type check struct {
+	checker Checker
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.Mutex
+	result Result
+}
+
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+	Cached    bool      `json:"cached,omitempty"`
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+const (
+	StatusOK           = "ok"
+	StatusFailing      = "failing"
+	StatusShuttingDown = "shutting_down"
+)
+
+type Health struct {
+	mu       sync.RWMutex
+	checks   []*check
+	draining atomic.Bool
+}
+
+func New() *Health {
+	return &Health{}
+}
+
+func (h *Health) Register(c Checker, opts ...CheckOption) {
+	ch := &check{checker: c, timeout: DefaultTimeout, ttl: DefaultCacheTTL}
+	for _, opt := range opts {
+		opt(ch)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, ch)
+}
+
+// SetNotReady makes readiness fail from now on, regardless of checks.
+func (h *Health) SetNotReady() {
+	h.draining.Store(true)
+}
+
+func (h *Health) Ready(ctx context.Context) Report {
+	if h.draining.Load() {
+		return Report{Status: StatusShuttingDown}
+	}
+
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+			res := c.run(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.checker.Name()] = res
+			if res.Status != StatusOK {
+				report.Status = StatusFailing
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (c *check) run(ctx context.Context) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl > 0 && !c.result.CheckedAt.IsZero() && time.Since(c.result.CheckedAt) < c.ttl {
+		res := c.result
+		res.Cached = true
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	c.result = Result{
+		Status:    StatusOK,
+		Duration:  time.Since(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		c.result.Status = StatusFailing
+		c.result.Error = err.Error()
+	}
+	return c.result
+}
+
+func (h *Health) LiveHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, Report{Status: StatusOK})
+	}
+}
+
+func (h *Health) ReadyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := h.Ready(c.Request.Context())
+		code := http.StatusOK
+		if report.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, report)
+	}
+}
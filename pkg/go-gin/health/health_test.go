@@ -0,0 +1,139 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReady(t *testing.T) {
+	errDown := errors.New("down")
+	tests := []struct {
+		name       string
+		checks     map[string]error
+		draining   bool
+		wantStatus string
+		wantCode   int
+	}{
+		{"no checks", nil, false, StatusOK, http.StatusOK},
+		{"all passing", map[string]error{"db": nil, "cache": nil}, false, StatusOK, http.StatusOK},
+		{"one failing", map[string]error{"db": nil, "cache": errDown}, false, StatusFailing, http.StatusServiceUnavailable},
+		{"shutting down", map[string]error{"db": nil}, true, StatusShuttingDown, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			for name, err := range tt.checks {
+				h.Register(NewChecker(name, func(context.Context) error { return err }))
+			}
+			if tt.draining {
+				h.SetNotReady()
+			}
+
+			w := serve(h.ReadyHandler())
+			if w.Code != tt.wantCode {
+				t.Fatalf("status code = %d, want %d", w.Code, tt.wantCode)
+			}
+			var report Report
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("decode report: %v", err)
+			}
+			if report.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", report.Status, tt.wantStatus)
+			}
+			if tt.draining {
+				if len(report.Checks) != 0 {
+					t.Fatalf("checks = %v, want none while shutting down", report.Checks)
+				}
+				return
+			}
+			for name, err := range tt.checks {
+				res := report.Checks[name]
+				if want := err == nil; (res.Status == StatusOK) != want {
+					t.Errorf("check %s = %+v", name, res)
+				}
+				if err != nil && res.Error != err.Error() {
+					t.Errorf("check %s error = %q, want %q", name, res.Error, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLiveIgnoresChecks(t *testing.T) {
+	h := New()
+	h.Register(NewChecker("db", func(context.Context) error { return errors.New("down") }))
+	h.SetNotReady()
+
+	if w := serve(h.LiveHandler()); w.Code != http.StatusOK {
+		t.Fatalf("live status code = %d, want 200", w.Code)
+	}
+}
+
+func TestCheckCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantCalls int32
+	}{
+		{"cached", time.Minute, 1},
+		{"caching disabled", 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			h := New()
+			h.Register(NewChecker("db", func(context.Context) error {
+				calls.Add(1)
+				return nil
+			}), WithCacheTTL(tt.ttl))
+
+			var last Report
+			for range 3 {
+				last = h.Ready(context.Background())
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("checker called %d times, want %d", got, tt.wantCalls)
+			}
+			if got := last.Checks["db"].Cached; got != (tt.ttl > 0) {
+				t.Fatalf("cached = %v, want %v", got, tt.ttl > 0)
+			}
+		})
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	h := New()
+	h.Register(NewChecker("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), WithTimeout(10*time.Millisecond))
+
+	done := make(chan Report, 1)
+	go func() { done <- h.Ready(context.Background()) }()
+
+	select {
+	case report := <-done:
+		if report.Status != StatusFailing || report.Checks["slow"].Error != context.DeadlineExceeded.Error() {
+			t.Fatalf("report = %+v, want the slow check failing with a deadline error", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Ready() did not honour the check timeout")
+	}
+}
+
+func serve(h gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", h)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w
+}
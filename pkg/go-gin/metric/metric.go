@@ -7,11 +7,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Metrics registers with a registry of its own unless WithRegistry is
+// given, so several instances can live in one process.
 type Metrics struct {
+	registerer      prometheus.Registerer
+	gatherer        prometheus.Gatherer
+	runtime         bool
+	handler         http.Handler
 	requestsTotal   *prometheus.CounterVec
 	requestDuration *prometheus.HistogramVec
 	username        string
@@ -37,32 +44,80 @@ func WithIgnoredMethods(methods ...string) Option {
 	}
 }
 
-func NewMetrics(opts ...Option) *Metrics {
-	m := &Metrics{
-		requestsTotal: promauto.NewCounterVec(
-			prometheus.CounterOpts{
-				Name: "http_requests_total",
-				Help: "Total number of HTTP requests",
-			},
-			[]string{"path", "method", "status"},
-		),
-		requestDuration: promauto.NewHistogramVec(
-			prometheus.HistogramOpts{
-				Name:    "http_request_duration_seconds",
-				Help:    "Duration of HTTP requests in seconds",
-				Buckets: prometheus.DefBuckets,
-			},
-			[]string{"path", "method", "status"},
-		),
+// WithRegistry registers the metrics with reg and serves gatherer from
+// Handler, e.g. prometheus.DefaultRegisterer and prometheus.DefaultGatherer
+// to share the global registry.
+func WithRegistry(reg prometheus.Registerer, gatherer prometheus.Gatherer) Option {
+	return func(m *Metrics) {
+		m.registerer = reg
+		m.gatherer = gatherer
 	}
+}
 
+// WithRuntimeMetrics adds the Go runtime and process collectors.
+func WithRuntimeMetrics() Option {
+	return func(m *Metrics) {
+		m.runtime = true
+	}
+}
+
+func NewMetrics(opts ...Option) *Metrics {
+	m := &Metrics{}
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.registerer == nil {
+		reg := prometheus.NewRegistry()
+		m.registerer, m.gatherer = reg, reg
+	}
+
+	if m.runtime {
+		for _, c := range []prometheus.Collector{
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		} {
+			// The default registry comes with both.
+			if err := m.registerer.Register(c); err != nil {
+				if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+					panic(err)
+				}
+			}
+		}
+	}
+
+	m.requestsTotal = promauto.With(m.registerer).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests",
+		},
+		[]string{"path", "method", "status"},
+	)
+	m.requestDuration = promauto.With(m.registerer).NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"path", "method", "status"},
+	)
+	m.handler = promhttp.InstrumentMetricHandler(m.registerer,
+		promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{}),
+	)
 
 	return m
 }
 
+// Registerer returns the registry the metrics are registered with, for other
+// collectors to be served by Handler too.
+func (m *Metrics) Registerer() prometheus.Registerer {
+	return m.registerer
+}
+
+// Gatherer returns what Handler serves.
+func (m *Metrics) Gatherer() prometheus.Gatherer {
+	return m.gatherer
+}
+
 func (m *Metrics) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -94,6 +149,6 @@ func (m *Metrics) Handler() gin.HandlerFunc {
 				return
 			}
 		}
-		promhttp.Handler().ServeHTTP(c.Writer, c.Request)
+		m.handler.ServeHTTP(c.Writer, c.Request)
 	}
 }
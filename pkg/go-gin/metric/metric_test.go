@@ -0,0 +1,33 @@
+package metric
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsInstancesAreIndependent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	a := NewMetrics(WithRuntimeMetrics())
+	b := NewMetrics(WithRuntimeMetrics())
+
+	r := gin.New()
+	r.Use(a.Middleware())
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	for range 3 {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	if got := testutil.ToFloat64(a.requestsTotal.WithLabelValues("/", "GET", "200")); got != 3 {
+		t.Fatalf("a counted %v requests, want 3", got)
+	}
+	if n, err := testutil.GatherAndCount(b.Gatherer(), "http_requests_total"); err != nil || n != 0 {
+		t.Fatalf("b has %d request series (err %v), want 0", n, err)
+	}
+	if n, err := testutil.GatherAndCount(a.Gatherer(), "go_goroutines"); err != nil || n != 1 {
+		t.Fatalf("a has %d go_goroutines series (err %v), want 1", n, err)
+	}
+}
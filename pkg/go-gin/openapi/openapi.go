@@ -0,0 +1,346 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+const Version = "3.1.0"
+
+const (
+	DefaultTitle   = "playground"
+	DefaultVersion = "0.0.0"
+)
+
+// Operation documents a route. Request and the Responses values are sample
+// values, e.g. &orderpb.OrderRequest{}, whose schema is derived by
+// reflection; a nil response means the response has no body.
+type Operation struct {
+	Method      string
+	Path        string // gin full path, e.g. /v1/orders/:id
+	ID          string // defaults to one derived from method and path
+	Summary     string
+	Description string
+	Tags        []string
+	Params      []Param
+	Request     any
+	Responses   map[int]any
+	ContentType string // of request and response bodies, default application/json
+}
+
+// Param documents a query, header or path parameter. Path parameters are
+// added from the route on their own and only need a Param for a
+// description.
+type Param struct {
+	Name        string
+	In          string // query (default), header or path
+	Description string
+	Required    bool
+	Type        any // sample value, default string
+}
+
+// Describer is implemented by modules that document their routes.
+type Describer interface {
+	Operations() []Operation
+}
+
+// Routes is satisfied by *gin.Engine.
+type Routes interface {
+	Routes() gin.RoutesInfo
+}
+
+type Option func(*Document)
+
+func WithTitle(title string) Option {
+	return func(d *Document) {
+		if title != "" {
+			d.info.Title = title
+		}
+	}
+}
+
+func WithVersion(version string) Option {
+	return func(d *Document) {
+		if version != "" {
+			d.info.Version = version
+		}
+	}
+}
+
+func WithDescription(description string) Option {
+	return func(d *Document) {
+		d.info.Description = description
+	}
+}
+
+// WithExclude leaves routes out of the document. Paths ending in "/" are
+// prefixes.
+func WithExclude(paths ...string) Option {
+	return func(d *Document) {
+		d.exclude = append(d.exclude, paths...)
+	}
+}
+
+// Document collects operations and renders them, together with every other
+// registered gin route, as an OpenAPI 3.1 document.
+type Document struct {
+	info    info
+	exclude []string
+
+	mu         sync.Mutex
+	operations map[string]Operation
+}
+
+func New(opts ...Option) *Document {
+	d := &Document{
+		info:       info{Title: DefaultTitle, Version: DefaultVersion},
+		operations: make(map[string]Operation),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Add documents operations, replacing earlier ones for the same method and
+// path.
+func (d *Document) Add(ops ...Operation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, op := range ops {
+		d.operations[op.Method+" "+op.Path] = op
+	}
+}
+
+// Build renders the document for routes. Routes without an Operation are
+// listed with an unspecified response.
+func (d *Document) Build(routes gin.RoutesInfo) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	g := newGenerator()
+	problemRef := g.schema(&problem.Problem{})
+
+	doc := document{
+		OpenAPI:    Version,
+		Info:       d.info,
+		Paths:      make(map[string]map[string]*operation),
+		Components: components{Schemas: g.schemas},
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	for _, r := range routes {
+		if d.excluded(r.Path) {
+			continue
+		}
+		path, params := templatePath(r.Path)
+
+		op, ok := d.operations[r.Method+" "+r.Path]
+		if !ok {
+			op = Operation{Method: r.Method, Path: r.Path}
+		}
+		out := g.operation(op, params)
+		if ok {
+			out.Responses["default"] = &response{
+				Description: "Error",
+				Content:     map[string]mediaType{problem.ContentType: {Schema: problemRef}},
+			}
+		} else {
+			out.Responses["default"] = &response{Description: "Undocumented"}
+		}
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(map[string]*operation)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(r.Method)] = out
+	}
+
+	return json.Marshal(doc)
+}
+
+// Handler serves the document. It is built on the first request, by which
+// time all routes are registered.
+func (d *Document) Handler(routes Routes) gin.HandlerFunc {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			body, err = d.Build(routes.Routes())
+		})
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", body)
+	}
+}
+
+func (d *Document) excluded(path string) bool {
+	for _, p := range d.exclude {
+		if path == p || strings.HasSuffix(p, "/") && strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// templatePath turns gin's :name and *name segments into {name} and returns
+// the parameter names.
+func templatePath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			params = append(params, s[1:])
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives e.g. getV1OrdersId from GET /v1/orders/:id.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, s := range strings.FieldsFunc(path, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(s[:1]) + s[1:])
+	}
+	return b.String()
+}
+
+func (g *generator) operation(op Operation, pathParams []string) *operation {
+	out := &operation{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]*response),
+	}
+	if out.OperationID == "" {
+		out.OperationID = operationID(op.Method, op.Path)
+	}
+
+	contentType := op.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	described := make(map[string]Param)
+	for _, p := range op.Params {
+		if p.In == "path" {
+			described[p.Name] = p
+		}
+	}
+	for _, name := range pathParams {
+		out.Parameters = append(out.Parameters, parameter{
+			Name:        name,
+			In:          "path",
+			Description: described[name].Description,
+			Required:    true,
+			Schema:      g.paramSchema(described[name].Type),
+		})
+	}
+	for _, p := range op.Params {
+		if p.In == "path" {
+			continue
+		}
+		in := p.In
+		if in == "" {
+			in = "query"
+		}
+		out.Parameters = append(out.Parameters, parameter{
+			Name:        p.Name,
+			In:          in,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      g.paramSchema(p.Type),
+		})
+	}
+
+	if op.Request != nil {
+		out.RequestBody = &requestBody{
+			Required: true,
+			Content:  map[string]mediaType{contentType: {Schema: g.schema(op.Request)}},
+		}
+	}
+
+	for code, body := range op.Responses {
+		resp := &response{Description: http.StatusText(code)}
+		if body != nil {
+			resp.Content = map[string]mediaType{contentType: {Schema: g.schema(body)}}
+		}
+		out.Responses[strconv.Itoa(code)] = resp
+	}
+	return out
+}
+
+func (g *generator) paramSchema(v any) *Schema {
+	if v == nil {
+		return &Schema{Type: "string"}
+	}
+	return g.schema(v)
+}
+
+type document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       info                             `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []parameter          `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
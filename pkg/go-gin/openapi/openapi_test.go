@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Base struct {
+	ID string `json:"id"`
+}
+
+type Item struct {
+	Base
+	Name     string            `json:"name"`
+	Note     *string           `json:"note,omitempty"`
+	Tags     []string          `json:"tags,omitzero"`
+	Labels   map[string]int    `json:"labels,omitempty"`
+	Data     []byte            `json:"data,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Created  time.Time         `json:"created"`
+	Children []Item            `json:"children,omitempty"`
+	Meta     map[string]string `json:"-"`
+	Untagged bool
+	secret   string
+}
+
+func TestGoSchema(t *testing.T) {
+	g := newGenerator()
+	if got := g.schema(&Item{}); got.Ref != "#/components/schemas/openapi.Item" {
+		t.Fatalf("schema ref = %q", got.Ref)
+	}
+
+	want := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "string"},
+			"name":     {Type: "string"},
+			"note":     {Type: "string"},
+			"tags":     {Type: "array", Items: &Schema{Type: "string"}},
+			"labels":   {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int64"}},
+			"data":     {Type: "string", Format: "byte"},
+			"raw":      {},
+			"created":  {Type: "string", Format: "date-time"},
+			"children": {Type: "array", Items: ref("openapi.Item")},
+			"Untagged": {Type: "boolean"},
+		},
+		Required: []string{"id", "name", "created", "Untagged"},
+	}
+	if got := g.schemas["openapi.Item"]; !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(want)
+		t.Fatalf("schema = %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+func TestMessageSchema(t *testing.T) {
+	g := newGenerator()
+	g.schema(&orderpb.OrderNote{})
+
+	got := g.schemas["order.OrderNote"]
+	if got == nil {
+		t.Fatalf("no schema for order.OrderNote, have %v", reflect.ValueOf(g.schemas).MapKeys())
+	}
+	tests := map[string]*Schema{
+		"noteId":    {Type: "string"},
+		"createdAt": {Type: "string", Format: "date-time"},
+		"updatedAt": {Type: "string", Format: "date-time"},
+	}
+	for name, want := range tests {
+		if !reflect.DeepEqual(got.Properties[name], want) {
+			t.Errorf("%s = %+v, want %+v", name, got.Properties[name], want)
+		}
+	}
+	visibility := got.Properties["visibility"]
+	if visibility == nil || visibility.Type != "string" || len(visibility.Enum) == 0 {
+		t.Errorf("visibility = %+v, want a string enum", visibility)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := func(c *gin.Context) {}
+	r.GET("/v1/items/:id", h)
+	r.POST("/v1/items", h)
+	r.GET("/v1/files/*path", h)
+	r.GET("/internal/debug", h)
+	r.GET("/metrics", h)
+
+	d := New(WithTitle("items"), WithVersion("1.2.3"), WithExclude("/internal/", "/metrics"))
+	d.Add(
+		Operation{
+			Method:    http.MethodGet,
+			Path:      "/v1/items/:id",
+			Summary:   "Get an item",
+			Params:    []Param{{Name: "id", In: "path", Description: "item ID"}, {Name: "view", Required: true}},
+			Responses: map[int]any{http.StatusOK: &Item{}},
+		},
+		Operation{
+			Method:    http.MethodPost,
+			Path:      "/v1/items",
+			ID:        "createItem",
+			Request:   &Item{},
+			Responses: map[int]any{http.StatusCreated: &Item{}, http.StatusNoContent: nil},
+		},
+	)
+	r.GET("/openapi.json", d.Handler(r))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	var doc struct {
+		OpenAPI string
+		Info    info
+		Paths   map[string]map[string]struct {
+			OperationID string
+			Summary     string
+			Parameters  []parameter
+			RequestBody *requestBody
+			Responses   map[string]response
+		}
+		Components components
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPI != Version || doc.Info.Title != "items" || doc.Info.Version != "1.2.3" {
+		t.Fatalf("header = %s %+v", doc.OpenAPI, doc.Info)
+	}
+	for _, p := range []string{"/internal/debug", "/metrics"} {
+		if _, ok := doc.Paths[p]; ok {
+			t.Errorf("excluded path %s is documented", p)
+		}
+	}
+
+	get := doc.Paths["/v1/items/{id}"]["get"]
+	if get.OperationID != "getV1ItemsId" || get.Summary != "Get an item" {
+		t.Errorf("GET item = %+v", get)
+	}
+	wantParams := []parameter{
+		{Name: "id", In: "path", Description: "item ID", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "view", In: "query", Required: true, Schema: &Schema{Type: "string"}},
+	}
+	if !reflect.DeepEqual(get.Parameters, wantParams) {
+		t.Errorf("GET item parameters = %+v", get.Parameters)
+	}
+	if got := get.Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/openapi.Item" {
+		t.Errorf("GET item 200 schema = %q", got)
+	}
+	if got := get.Responses["default"].Content["application/problem+json"].Schema.Ref; got != "#/components/schemas/problem.Problem" {
+		t.Errorf("GET item default schema = %q", got)
+	}
+
+	post := doc.Paths["/v1/items"]["post"]
+	if post.OperationID != "createItem" || post.RequestBody == nil || !post.RequestBody.Required {
+		t.Errorf("POST items = %+v", post)
+	}
+	if resp := post.Responses["204"]; resp.Description != "No Content" || resp.Content != nil {
+		t.Errorf("POST items 204 = %+v", resp)
+	}
+
+	files := doc.Paths["/v1/files/{path}"]["get"]
+	if len(files.Parameters) != 1 || files.Parameters[0].Name != "path" {
+		t.Errorf("catch-all parameters = %+v", files.Parameters)
+	}
+	if files.Responses["default"].Description != "Undocumented" {
+		t.Errorf("undocumented route responses = %+v", files.Responses)
+	}
+	for _, name := range []string{"openapi.Item", "problem.Problem", "problem.FieldError"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("components lack %s", name)
+		}
+	}
+}
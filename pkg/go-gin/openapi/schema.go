@@ -0,0 +1,237 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Schema is the subset of JSON Schema the generator emits.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	rawType       = reflect.TypeFor[json.RawMessage]()
+	messageType   = reflect.TypeFor[proto.Message]()
+	enumType      = reflect.TypeFor[protoreflect.Enum]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// generator derives schemas from Go and protobuf types. Named structs and
+// messages go to components and are referenced from where they are used.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{schemas: make(map[string]*Schema)}
+}
+
+func (g *generator) schema(v any) *Schema {
+	return g.goType(reflect.TypeOf(v))
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// goType follows encoding/json, except that protobuf messages and enums are
+// described the way protojson encodes them.
+func (g *generator) goType(t reflect.Type) *Schema {
+	if t.Implements(messageType) && t.Kind() == reflect.Pointer {
+		m := reflect.New(t.Elem()).Interface().(proto.Message)
+		return g.message(m.ProtoReflect().Descriptor())
+	}
+	if t.Implements(enumType) {
+		e := reflect.Zero(t).Interface().(protoreflect.Enum)
+		return enumSchema(e.Descriptor())
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawType:
+		return &Schema{}
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.goType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.goType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = &Schema{} // placeholder for recursive types
+			g.schemas[name] = g.object(t)
+		}
+		return ref(name)
+	}
+	return &Schema{}
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(s, t)
+	return s
+}
+
+func (g *generator) fields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = g.goType(f.Type)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func (g *generator) message(md protoreflect.MessageDescriptor) *Schema {
+	if s, ok := wellKnown(md); ok {
+		return s
+	}
+
+	name := string(md.FullName())
+	if _, ok := g.schemas[name]; !ok {
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		g.schemas[name] = s
+		fields := md.Fields()
+		for i := range fields.Len() {
+			f := fields.Get(i)
+			s.Properties[f.JSONName()] = g.field(f)
+		}
+	}
+	return ref(name)
+}
+
+func (g *generator) field(f protoreflect.FieldDescriptor) *Schema {
+	switch {
+	case f.IsMap():
+		return &Schema{Type: "object", AdditionalProperties: g.singular(f.MapValue())}
+	case f.IsList():
+		return &Schema{Type: "array", Items: g.singular(f)}
+	}
+	return g.singular(f)
+}
+
+// singular follows protojson: 64-bit integers are strings, enums are their
+// value names.
+func (g *generator) singular(f protoreflect.FieldDescriptor) *Schema {
+	switch f.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		return enumSchema(f.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.message(f.Message())
+	}
+	return &Schema{}
+}
+
+func enumSchema(ed protoreflect.EnumDescriptor) *Schema {
+	s := &Schema{Type: "string"}
+	values := ed.Values()
+	for i := range values.Len() {
+		s.Enum = append(s.Enum, string(values.Get(i).Name()))
+	}
+	return s
+}
+
+func wellKnown(md protoreflect.MessageDescriptor) (*Schema, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}, true
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return &Schema{Type: "string"}, true
+	case "google.protobuf.Struct", "google.protobuf.Empty", "google.protobuf.Any":
+		return &Schema{Type: "object"}, true
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array", Items: &Schema{}}, true
+	case "google.protobuf.Value":
+		return &Schema{}, true
+	}
+	// Wrappers such as google.protobuf.StringValue encode as their value.
+	if md.ParentFile().Package() == "google.protobuf" && strings.HasSuffix(string(md.Name()), "Value") {
+		if v := md.Fields().ByName("value"); v != nil {
+			return (&generator{}).singular(v), true
+		}
+	}
+	return nil, false
+}
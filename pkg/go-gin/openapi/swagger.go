@@ -0,0 +1,39 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	swaggerfiles "github.com/swaggo/files/v2"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SwaggerUI serves the embedded Swagger UI pointed at specURL. Mount it on
+// a catch-all route named filepath, e.g. /docs/*filepath.
+func SwaggerUI(specURL string) gin.HandlerFunc {
+	url, _ := json.Marshal(specURL)
+	initializer := []byte(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: ` + string(url) + `,
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+`)
+	files := http.FS(swaggerfiles.FS)
+
+	return func(c *gin.Context) {
+		switch name := strings.TrimPrefix(c.Param("filepath"), "/"); name {
+		case "", "index.html":
+			c.FileFromFS("/", files)
+		case "swagger-initializer.js":
+			c.Data(http.StatusOK, "text/javascript; charset=utf-8", initializer)
+		default:
+			c.FileFromFS(name, files)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const ContentType = "application/problem+json"
+
+// StatusClientClosedRequest is nginx's non-standard status for requests the
+// client gave up on.
+const StatusClientClosedRequest = 499
+
+// Problem is an RFC 7807 problem details object. It is also an error, so
+// handlers can return one from Handler or pass it to Error.
+type Problem struct {
+	Type      string       `json:"type,omitempty"` // absent means "about:blank"
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code,omitempty"` // gRPC code of upstream errors
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"` // invalid fields of a 400
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+	}
+	return fmt.Sprintf("%d %s", p.Status, p.Title)
+}
+
+// New returns a problem with the standard title for status.
+func New(status int, detail string) *Problem {
+	title := http.StatusText(status)
+	if status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+	return &Problem{
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func Newf(status int, format string, args ...any) *Problem {
+	return New(status, fmt.Sprintf(format, args...))
+}
+
+func BadRequest(detail string) *Problem   { return New(http.StatusBadRequest, detail) }
+func Unauthorized(detail string) *Problem { return New(http.StatusUnauthorized, detail) }
+func Forbidden(detail string) *Problem    { return New(http.StatusForbidden, detail) }
+func NotFound(detail string) *Problem     { return New(http.StatusNotFound, detail) }
+func Conflict(detail string) *Problem     { return New(http.StatusConflict, detail) }
+func Internal(detail string) *Problem     { return New(http.StatusInternalServerError, detail) }
+
+// FieldError describes one invalid request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`   // body, query or path
+	Code    string `json:"code"` // validator tag, or "type" for values that don't decode
+	Message string `json:"message"`
+}
+
+// Invalid returns a 400 listing every invalid field.
+func Invalid(errs ...FieldError) *Problem {
+	p := New(http.StatusBadRequest, "1 invalid field")
+	if len(errs) != 1 {
+		p.Detail = fmt.Sprintf("%d invalid fields", len(errs))
+	}
+	p.Errors = errs
+	return p
+}
+
+// Write aborts the request with p, filling in the request path and ID.
+func Write(c *gin.Context, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = requestid.Get(c)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		c.AbortWithStatus(p.Status)
+		return
+	}
+	c.Abort()
+	c.Data(p.Status, ContentType, body)
+}
+
+// Error converts err to a problem and writes it: a *Problem as is, gRPC
+// status errors with their HTTP equivalent and anything else as a 500
+// whose details are logged rather than returned.
+func Error(c *gin.Context, err error) {
+	Write(c, From(c, err))
+}
+
+func From(c *gin.Context, err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return Newf(http.StatusRequestEntityTooLarge, "request body exceeds %d bytes", tooLarge.Limit)
+	}
+
+	if st, ok := status.FromError(err); ok {
+		p := New(HTTPStatus(st.Code()), st.Message())
+		p.Code = st.Code().String()
+		return p
+	}
+
+	c.Error(err)
+	slog.ErrorContext(c.Request.Context(), "unhandled error", "error", err, "request_id", requestid.Get(c))
+	return Internal("")
+}
+
+// Handler adapts a handler that returns an error; a non-nil error is
+// written with Error unless the handler already sent a response.
+func Handler(fn func(c *gin.Context) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := fn(c); err != nil && !c.Writer.Written() {
+			Error(c, err)
+		}
+	}
+}
+
+// HTTPStatus maps a gRPC code to an HTTP status.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return StatusClientClosedRequest
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,158 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantTitle  string
+		wantDetail string
+		wantCode   string
+	}{
+		{
+			name:       "problem",
+			err:        Conflict("order already shipped"),
+			wantStatus: http.StatusConflict,
+			wantTitle:  "Conflict",
+			wantDetail: "order already shipped",
+		},
+		{
+			name:       "wrapped problem",
+			err:        fmt.Errorf("update: %w", NotFound("no such order")),
+			wantStatus: http.StatusNotFound,
+			wantTitle:  "Not Found",
+			wantDetail: "no such order",
+		},
+		{
+			name:       "gRPC status",
+			err:        status.Error(codes.FailedPrecondition, "order is cancelled"),
+			wantStatus: http.StatusBadRequest,
+			wantTitle:  "Bad Request",
+			wantDetail: "order is cancelled",
+			wantCode:   "FailedPrecondition",
+		},
+		{
+			name:       "client gave up",
+			err:        status.Error(codes.Canceled, "context canceled"),
+			wantStatus: StatusClientClosedRequest,
+			wantTitle:  "Client Closed Request",
+			wantDetail: "context canceled",
+			wantCode:   "Canceled",
+		},
+		{
+			name:       "body too large",
+			err:        &http.MaxBytesError{Limit: 16},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantTitle:  "Request Entity Too Large",
+			wantDetail: "request body exceeds 16 bytes",
+		},
+		{
+			name:       "anything else is not disclosed",
+			err:        errors.New("dial tcp 10.0.0.1:9091: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantTitle:  "Internal Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set(requestid.ContextKey, "req-1") })
+			r.GET("/orders/:id", Handler(func(*gin.Context) error { return tt.err }))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/o-1", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ContentType {
+				t.Fatalf("Content-Type = %q, want %q", ct, ContentType)
+			}
+			var got Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			want := Problem{
+				Title:     tt.wantTitle,
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  "/orders/o-1",
+				Code:      tt.wantCode,
+				RequestID: "req-1",
+			}
+			if got.Type != "" || got.Title != want.Title || got.Status != want.Status || got.Detail != want.Detail ||
+				got.Instance != want.Instance || got.Code != want.Code || got.RequestID != want.RequestID {
+				t.Fatalf("problem = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestHandlerKeepsWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", Handler(func(c *gin.Context) error {
+		c.String(http.StatusAccepted, "queued")
+		return errors.New("late failure")
+	}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusAccepted || w.Body.String() != "queued" {
+		t.Fatalf("response = %d %q, want 202 queued", w.Code, w.Body)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	one := Invalid(FieldError{Field: "name", In: "body", Code: "required", Message: "is required"})
+	two := Invalid(FieldError{Field: "a"}, FieldError{Field: "b"})
+	if one.Status != http.StatusBadRequest || one.Detail != "1 invalid field" {
+		t.Fatalf("Invalid(1) = %+v", one)
+	}
+	if two.Detail != "2 invalid fields" || len(two.Errors) != 2 {
+		t.Fatalf("Invalid(2) = %+v", two)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.OutOfRange:         http.StatusBadRequest,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.Aborted:            http.StatusConflict,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.Unimplemented:      http.StatusNotImplemented,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.DataLoss:           http.StatusInternalServerError,
+		codes.Unknown:            http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := HTTPStatus(code); got != want {
+			t.Errorf("HTTPStatus(%s) = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/auth"
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const sweepInterval = time.Minute
+
+// KeyFunc identifies the client a request is charged to. An empty key falls
+// back to the client IP.
+type KeyFunc func(c *gin.Context) string
+
+func ByClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// BySubject charges requests to the authenticated caller, the API key name
+// or the JWT subject, and anonymous ones to their client IP. The limiter has
+// to run after the auth middleware.
+func BySubject(c *gin.Context) string {
+	if claims, ok := auth.GetClaims(c); ok && claims.Subject != "" {
+		return "sub:" + claims.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+type Limit struct {
+	Rate  float64 // tokens per second
+	Burst int
+}
+
+type Limiter struct {
+	limit     Limit
+	routes    map[string]Limit // "METHOD /full/path" -> limit
+	skipPaths map[string]struct{}
+	keyFunc   KeyFunc
+	now       func() time.Time
+	throttled *prometheus.CounterVec
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type Option func(*Limiter)
+
+func WithKeyFunc(fn KeyFunc) Option {
+	return func(l *Limiter) {
+		l.keyFunc = fn
+	}
+}
+
+// WithRouteLimit overrides the limit for one route, identified by method
+// and gin full path, e.g. ("POST", "/v1/orders").
+func WithRouteLimit(method, path string, limit Limit) Option {
+	return func(l *Limiter) {
+		l.routes[method+" "+path] = limit
+	}
+}
+
+// WithSkipPaths exempts paths such as probes and /metrics from limiting.
+func WithSkipPaths(paths ...string) Option {
+	return func(l *Limiter) {
+		for _, p := range paths {
+			l.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRegisterer registers the limiter's metrics with reg. Without it they
+// go to a registry of the limiter's own and aren't exported.
+func WithRegisterer(reg prometheus.Registerer) Option {
+	return func(l *Limiter) {
+		l.throttled = newThrottledCounter(reg)
+	}
+}
+
+func newThrottledCounter(reg prometheus.Registerer) *prometheus.CounterVec {
+	return promauto.With(reg).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_throttled_total",
+			Help: "Total number of HTTP requests rejected by the rate limiter",
+		},
+		[]string{"path", "method"},
+	)
+}
+
+func New(limit Limit, opts ...Option) *Limiter {
+	l := &Limiter{
+		limit:     limit,
+		routes:    make(map[string]Limit),
+		skipPaths: make(map[string]struct{}),
+		keyFunc:   ByClientIP,
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.throttled == nil {
+		l.throttled = newThrottledCounter(prometheus.NewRegistry())
+	}
+	l.lastSweep = l.now()
+	return l
+}
+
+func (l *Limiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		if _, ok := l.skipPaths[path]; ok {
+			c.Next()
+			return
+		}
+
+		key := l.keyFunc(c)
+		if key == "" {
+			key = c.ClientIP()
+		}
+
+		limit := l.limit
+		bucketKey := key
+		if route, ok := l.routes[c.Request.Method+" "+path]; ok {
+			limit = route
+			bucketKey = c.Request.Method + " " + path + "|" + key
+		}
+
+		allowed, remaining, retryAfter, reset := l.take(bucketKey, limit)
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		h.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+
+		if !allowed {
+			l.throttled.WithLabelValues(path, c.Request.Method).Inc()
+			h.Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+			problem.Write(c, problem.New(http.StatusTooManyRequests, "rate limit exceeded"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (l *Limiter) take(key string, limit Limit) (allowed bool, remaining int, retryAfter, reset time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(limit.Rate, limit.Burst, now)
+		l.buckets[key] = b
+	}
+
+	allowed = b.take(now)
+	return allowed, b.remaining(), b.untilAvailable(), b.untilFull()
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket for
+// the same key would be identical. Callers must hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
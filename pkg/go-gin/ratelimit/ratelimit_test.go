@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/go-gin/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestBySubject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	l := New(Limit{Rate: 0.001, Burst: 1},
+		WithKeyFunc(BySubject),
+		WithRegisterer(prometheus.NewRegistry()),
+	)
+	r := gin.New()
+	// Stands in for the auth middleware.
+	r.Use(func(c *gin.Context) {
+		if sub := c.GetHeader("X-Test-Subject"); sub != "" {
+			c.Set(auth.ClaimsKey, &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: sub}})
+		}
+	}, l.Middleware())
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(remoteAddr, subject, apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if subject != "" {
+			req.Header.Set("X-Test-Subject", subject)
+		}
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		subject    string
+		apiKey     string
+		want       int
+	}{
+		{"first anonymous request", "10.0.0.1:1000", "", "", http.StatusOK},
+		{"rotating an unverified header does not reset the bucket", "10.0.0.1:1001", "", "rotated", http.StatusTooManyRequests},
+		{"other client IP", "10.0.0.2:1000", "", "", http.StatusOK},
+		{"authenticated caller from a limited IP", "10.0.0.1:1000", "alice", "", http.StatusOK},
+		{"same subject from another IP", "10.0.0.3:1000", "alice", "", http.StatusTooManyRequests},
+		{"other subject", "10.0.0.3:1000", "bob", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got := do(tt.remoteAddr, tt.subject, tt.apiKey); got != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewWithoutRegisterer(t *testing.T) {
+	// Each limiter gets a registry of its own, so this must not panic on
+	// duplicate registration.
+	for range 2 {
+		New(Limit{Rate: 1, Burst: 1})
+	}
+}
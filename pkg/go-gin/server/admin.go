@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/braden0236/playground/pkg/admin"
+	"github.com/braden0236/playground/pkg/go-gin/health"
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithAdmin mounts the admin routes under admin.Prefix, unless a has no
+// credentials. While maintenance mode is on, /readyz fails and all other
+// routes except probes and metrics answer 503.
+func WithAdmin(a *admin.Admin) Option {
+	h := gin.WrapH(a.Handler())
+	m := a.Maintenance()
+
+	return func(o *options) {
+		if a.Enabled() {
+			WithRoutes(admin.Prefix, func(r gin.IRouter) {
+				r.Any("/*path", h)
+			})(o)
+		} else {
+			slog.Warn("admin routes disabled, no metrics credentials set")
+		}
+		WithMiddleware(AfterMetrics, maintenanceMiddleware(m))(o)
+		WithChecker(health.NewChecker("maintenance", func(context.Context) error {
+			return m.Check()
+		}), health.WithCacheTTL(0))(o)
+	}
+}
+
+func maintenanceMiddleware(m *admin.Maintenance) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.Enabled() {
+			c.Next()
+			return
+		}
+
+		switch path := c.Request.URL.Path; {
+		case path == "/healthz", path == "/livez", path == "/readyz", path == "/metrics",
+			strings.HasPrefix(path, admin.Prefix+"/"):
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", "60")
+		problem.Write(c, problem.New(http.StatusServiceUnavailable, "service under maintenance"))
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recovery turns panics into a 500 problem carrying the request ID and
+// logs the stack, instead of gin's plain text dump to stderr.
+func recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "panic recovered",
+			"panic", err,
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"request_id", requestid.Get(c),
+			"stack", string(debug.Stack()),
+		)
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		problem.Write(c, problem.Internal(""))
+	})
+}
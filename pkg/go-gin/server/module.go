@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/health"
+	"github.com/braden0236/playground/pkg/go-gin/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Module contributes routes to a Server. A Module that also implements
+// Starter or Stopper takes part in the server lifecycle.
+type Module interface {
+	Register(r gin.IRouter)
+}
+
+// Starter is run before the server starts accepting connections. An error
+// aborts Run after the modules already started are stopped, in reverse
+// order.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is run as a shutdown hook, in registration order alongside hooks
+// added with WithShutdownHook. A module that is also a Starter is only
+// stopped if it started.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// MiddlewarePosition places middleware relative to the built-in chain of
+// logger, recovery and metrics.
+type MiddlewarePosition int
+
+const (
+	// AfterMetrics runs inside the metrics middleware, so requests it
+	// rejects are still counted. This is the default.
+	AfterMetrics MiddlewarePosition = iota
+	// BeforeMetrics runs after recovery but before metrics.
+	BeforeMetrics
+	// BeforeRecovery runs ahead of the logger and recovery; panics raised
+	// here are not recovered.
+	BeforeRecovery
+)
+
+type startFunc func(ctx context.Context) error
+
+func (f startFunc) Register(gin.IRouter) {}
+
+func (f startFunc) Start(ctx context.Context) error {
+	return f(ctx)
+}
+
+type routesFunc struct {
+	prefix     string
+	middleware []gin.HandlerFunc
+	register   func(r gin.IRouter)
+}
+
+func (m routesFunc) Register(r gin.IRouter) {
+	m.register(r.Group(m.prefix, m.middleware...))
+}
+
+type options struct {
+	modules    []Module
+	hooks      []ShutdownHook
+	middleware map[MiddlewarePosition][]gin.HandlerFunc
+	health     *health.Health
+	openapi    *openapi.Document
+	swaggerUI  bool
+	onShutdown []func()
+
+	trustedProxies []string
+}
+
+type Option func(*options)
+
+func WithModule(modules ...Module) Option {
+	return func(o *options) {
+		for _, m := range modules {
+			o.modules = append(o.modules, m)
+			if stopper, ok := m.(Stopper); ok {
+				o.hooks = append(o.hooks, ShutdownHook{
+					Name:   fmt.Sprintf("%T", m),
+					Fn:     stopper.Stop,
+					module: len(o.modules),
+				})
+			}
+		}
+	}
+}
+
+// WithRoutes registers routes on a group under prefix with its own
+// middleware.
+func WithRoutes(prefix string, register func(r gin.IRouter), middleware ...gin.HandlerFunc) Option {
+	return WithModule(routesFunc{prefix: prefix, middleware: middleware, register: register})
+}
+
+func WithMiddleware(pos MiddlewarePosition, middleware ...gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.middleware[pos] = append(o.middleware[pos], middleware...)
+	}
+}
+
+func WithStartHook(fn func(ctx context.Context) error) Option {
+	return WithModule(startFunc(fn))
+}
+
+type HookOption func(*ShutdownHook)
+
+// WithHookName names the hook in logs and errors.
+func WithHookName(name string) HookOption {
+	return func(h *ShutdownHook) {
+		h.Name = name
+	}
+}
+
+// WithHookTimeout bounds the hook's context. Without it the hook uses
+// whatever remains of the shutdown deadline.
+func WithHookTimeout(d time.Duration) HookOption {
+	return func(h *ShutdownHook) {
+		h.Timeout = d
+	}
+}
+
+// WithShutdownHook appends fn to the shutdown chain. fn must return once its
+// context is done; the chain waits for it.
+func WithShutdownHook(fn func(ctx context.Context) error, opts ...HookOption) Option {
+	return func(o *options) {
+		h := ShutdownHook{Name: fmt.Sprintf("hook-%d", len(o.hooks)+1), Fn: fn}
+		for _, opt := range opts {
+			opt(&h)
+		}
+		o.hooks = append(o.hooks, h)
+	}
+}
+
+// WithOnShutdown runs fn as soon as shutdown begins, while in-flight
+// requests are still being waited for. Long-lived responses such as event
+// streams use it to finish instead of holding shutdown to its deadline.
+func WithOnShutdown(fn func()) Option {
+	return func(o *options) {
+		o.onShutdown = append(o.onShutdown, fn)
+	}
+}
+
+// WithTrustedProxies makes the client IP, used by the access log and rate
+// limiting, come from X-Forwarded-For or X-Real-IP when the peer is one of
+// proxies, IPs or CIDRs. Without it no peer is trusted and the client IP is
+// always the peer address.
+func WithTrustedProxies(proxies ...string) Option {
+	return func(o *options) {
+		o.trustedProxies = append(o.trustedProxies, proxies...)
+	}
+}
+
+// WithChecker adds a dependency check to /readyz.
+func WithChecker(c health.Checker, opts ...health.CheckOption) Option {
+	return func(o *options) {
+		o.health.Register(c, opts...)
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/metric"
+
+	"github.com/gin-gonic/gin"
+)
+
+type lifecycleModule struct {
+	name     string
+	startErr error
+	calls    *[]string
+}
+
+func (m lifecycleModule) Register(gin.IRouter) {}
+
+func (m lifecycleModule) Start(context.Context) error {
+	*m.calls = append(*m.calls, "start "+m.name)
+	return m.startErr
+}
+
+func (m lifecycleModule) Stop(context.Context) error {
+	*m.calls = append(*m.calls, "stop "+m.name)
+	return nil
+}
+
+func TestStartFailureStopsStartedModules(t *testing.T) {
+	var calls []string
+	errBoom := errors.New("boom")
+	s := NewServer(metric.NewMetrics(),
+		WithModule(
+			lifecycleModule{name: "a", calls: &calls},
+			lifecycleModule{name: "b", calls: &calls},
+			lifecycleModule{name: "c", startErr: errBoom, calls: &calls},
+			lifecycleModule{name: "d", calls: &calls},
+		),
+	)
+
+	err := s.Start(context.Background())
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Start() error = %v, want %v", err, errBoom)
+	}
+	want := []string{"start a", "start b", "start c", "stop b", "stop a"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %q, want %q", calls, want)
+	}
+
+	// Shutdown must not stop the rolled back or never started modules again.
+	calls = nil
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("Shutdown() calls = %q, want none", calls)
+	}
+}
+
+func TestShutdownStopsStartedModulesInOrder(t *testing.T) {
+	var calls []string
+	s := NewServer(metric.NewMetrics(),
+		WithModule(
+			lifecycleModule{name: "a", calls: &calls},
+			lifecycleModule{name: "b", calls: &calls},
+		),
+	)
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	want := []string{"start a", "start b", "stop a", "stop b"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestShutdownHookOptions(t *testing.T) {
+	errClose := errors.New("close failed")
+	var gotDeadline bool
+	s := NewServer(metric.NewMetrics(),
+		WithShutdownHook(func(ctx context.Context) error {
+			_, gotDeadline = ctx.Deadline()
+			return nil
+		}, WithHookTimeout(time.Second)),
+		WithShutdownHook(func(context.Context) error { return errClose }, WithHookName("conn")),
+	)
+
+	err := s.Shutdown(context.Background())
+	if !errors.Is(err, errClose) {
+		t.Fatalf("Shutdown() error = %v, want %v", err, errClose)
+	}
+	if want := "shutdown hook conn: close failed"; err.Error() != want {
+		t.Fatalf("Shutdown() error = %q, want %q", err, want)
+	}
+	if !gotDeadline {
+		t.Fatal("hook with WithHookTimeout got no deadline")
+	}
+}
+
+func TestServersShareAProcess(t *testing.T) {
+	for range 2 {
+		s := NewServer(metric.NewMetrics(metric.WithRuntimeMetrics()))
+		w := httptest.NewRecorder()
+		s.Engine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET /metrics = %d, want 200", w.Code)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/braden0236/playground/pkg/go-gin/health"
+	"github.com/braden0236/playground/pkg/go-gin/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	OpenAPIPath   = "/openapi.json"
+	SwaggerUIPath = "/docs"
+)
+
+// WithOpenAPI serves doc at OpenAPIPath, documenting the probes, metrics and
+// the operations of every module that implements openapi.Describer.
+func WithOpenAPI(doc *openapi.Document) Option {
+	return func(o *options) {
+		o.openapi = doc
+	}
+}
+
+// WithSwaggerUI serves the Swagger UI under SwaggerUIPath. It needs
+// WithOpenAPI.
+func WithSwaggerUI() Option {
+	return func(o *options) {
+		o.swaggerUI = true
+	}
+}
+
+func mountOpenAPI(r *gin.Engine, doc *openapi.Document, modules []Module, swaggerUI bool) {
+	doc.Add(builtinOperations()...)
+	for _, mod := range modules {
+		if d, ok := mod.(openapi.Describer); ok {
+			doc.Add(d.Operations()...)
+		}
+	}
+
+	r.GET(OpenAPIPath, doc.Handler(r))
+	if swaggerUI {
+		openapi.WithExclude(SwaggerUIPath + "/")(doc)
+		r.GET(SwaggerUIPath+"/*filepath", openapi.SwaggerUI(OpenAPIPath))
+	}
+}
+
+func builtinOperations() []openapi.Operation {
+	probe := func(path, summary string, responses map[int]any) openapi.Operation {
+		return openapi.Operation{
+			Method:    http.MethodGet,
+			Path:      path,
+			Summary:   summary,
+			Tags:      []string{"probes"},
+			Responses: responses,
+		}
+	}
+	live := map[int]any{http.StatusOK: health.Report{}}
+	ready := map[int]any{http.StatusOK: health.Report{}, http.StatusServiceUnavailable: health.Report{}}
+
+	return []openapi.Operation{
+		probe("/healthz", "Liveness probe", live),
+		probe("/livez", "Liveness probe", live),
+		probe("/readyz", "Readiness probe with dependency checks", ready),
+		{
+			Method:      http.MethodGet,
+			Path:        "/metrics",
+			Summary:     "Prometheus metrics",
+			Tags:        []string{"metrics"},
+			Responses:   map[int]any{http.StatusOK: ""},
+			ContentType: "text/plain",
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      OpenAPIPath,
+			Summary:   "This document",
+			Tags:      []string{"docs"},
+			Responses: map[int]any{http.StatusOK: map[string]any{}},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/sse"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	OrderCreated = "order.created"
+	OrderUpdated = "order.updated"
+	OrderDeleted = "order.deleted"
+)
+
+// OrderEvent is the data of order events. Order is set when the publisher
+// knows the order's new state; otherwise clients fetch it.
+type OrderEvent struct {
+	OrderID string          `json:"order_id"`
+	Order   json.RawMessage `json:"order,omitempty"`
+}
+
+type GatewayOption func(*OrderGateway)
+
+// WithEventStream serves the broker's events at /v1/orders/events.
+func WithEventStream(b *sse.Broker, opts ...sse.Option) GatewayOption {
+	return func(g *OrderGateway) {
+		g.stream = sse.Handler(b, opts...)
+	}
+}
+
+// WithWriteEvents publishes the orders created, updated and deleted through
+// the gateway. It sees only its own writes; use an OrderPoller to follow
+// changes made elsewhere.
+func WithWriteEvents(b *sse.Broker) GatewayOption {
+	return func(g *OrderGateway) {
+		g.events = b
+	}
+}
+
+func (g *OrderGateway) publish(typ, orderID string) {
+	if g.events == nil {
+		return
+	}
+	if err := g.events.Publish(typ, OrderEvent{OrderID: orderID}); err != nil {
+		slog.Error("publish order event failed", "type", typ, "order_id", orderID, "error", err)
+	}
+}
+
+const orderPollPageSize = 100
+
+// OrderPoller publishes order changes by listing all orders at an interval
+// and comparing them with the previous listing. The first listing only
+// sets the baseline.
+type OrderPoller struct {
+	client   orderpb.OrderServiceClient
+	broker   *sse.Broker
+	interval time.Duration
+	timeout  time.Duration
+
+	orders map[string]*orderpb.OrderResponse
+	stop   chan struct{}
+}
+
+func NewOrderPoller(g *OrderGateway, b *sse.Broker, interval time.Duration) *OrderPoller {
+	return &OrderPoller{
+		client:   g.client,
+		broker:   b,
+		interval: interval,
+		timeout:  g.timeout,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (p *OrderPoller) Run() error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.poll(); err != nil {
+			slog.Warn("poll orders failed", "error", err)
+		}
+		select {
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *OrderPoller) Stop() {
+	close(p.stop)
+}
+
+func (p *OrderPoller) RunFunc() (func() error, func(error)) {
+	return p.Run, func(error) { p.Stop() }
+}
+
+func (p *OrderPoller) poll() error {
+	timeout := p.timeout
+	if timeout <= 0 {
+		timeout = p.interval
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	current := make(map[string]*orderpb.OrderResponse)
+	for page := int32(1); ; page++ {
+		resp, err := p.client.ListOrders(ctx, &orderpb.ListOrdersRequest{Page: page, PageSize: orderPollPageSize})
+		if err != nil {
+			return err
+		}
+		for _, o := range resp.Orders {
+			current[o.OrderId] = o
+		}
+		if len(resp.Orders) < orderPollPageSize || int(page)*orderPollPageSize >= int(resp.Total) {
+			break
+		}
+	}
+
+	previous := p.orders
+	p.orders = current
+	if previous == nil {
+		return nil
+	}
+
+	for id, o := range current {
+		old, ok := previous[id]
+		switch {
+		case !ok:
+			p.publish(OrderCreated, o)
+		case !proto.Equal(old, o):
+			p.publish(OrderUpdated, o)
+		}
+	}
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			p.publish(OrderDeleted, &orderpb.OrderResponse{OrderId: id})
+		}
+	}
+	return nil
+}
+
+func (p *OrderPoller) publish(typ string, o *orderpb.OrderResponse) {
+	ev := OrderEvent{OrderID: o.OrderId}
+	if typ != OrderDeleted {
+		body, err := protojson.Marshal(o)
+		if err != nil {
+			slog.Error("marshal order event failed", "order_id", o.OrderId, "error", err)
+			return
+		}
+		ev.Order = body
+	}
+	if err := p.broker.Publish(typ, ev); err != nil {
+		slog.Error("publish order event failed", "type", typ, "order_id", o.OrderId, "error", err)
+	}
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/braden0236/playground/pkg/go-gin/bind"
+	"github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/go-gin/health"
+	"github.com/braden0236/playground/pkg/go-gin/openapi"
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+	"github.com/braden0236/playground/pkg/go-gin/sse"
+	orderpb "github.com/braden0236/playground/pkg/go-grpc/order"
+	"github.com/braden0236/playground/pkg/requestid"
+	"github.com/braden0236/playground/pkg/tlsutil"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// OrderGateway exposes OrderService as REST/JSON under /v1/orders.
+type OrderGateway struct {
+	client  orderpb.OrderServiceClient
+	health  grpc_health_v1.HealthClient
+	timeout time.Duration
+	stream  gin.HandlerFunc
+	events  *sse.Broker
+}
+
+func NewOrderGateway(conn grpc.ClientConnInterface, timeout time.Duration, opts ...GatewayOption) *OrderGateway {
+	g := &OrderGateway{
+		client:  orderpb.NewOrderServiceClient(conn),
+		health:  grpc_health_v1.NewHealthClient(conn),
+		timeout: timeout,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Checker reports the order service ready while its gRPC health service
+// answers SERVING.
+func (g *OrderGateway) Checker() health.Checker {
+	return health.NewChecker("order_service", func(ctx context.Context) error {
+		resp, err := g.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			return errors.New("order service is " + resp.Status.String())
+		}
+		return nil
+	})
+}
+
+// DialOrderService opens a client connection to OrderService as configured.
+func DialOrderService(conf config.OrderServiceConfig) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if conf.UseTLS {
+		tlsConfig, err := tlsutil.BuildClientConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	return grpc.NewClient(conf.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(requestid.StreamClientInterceptor()),
+	)
+}
+
+func (g *OrderGateway) Register(r gin.IRouter) {
+	orders := r.Group("/v1/orders")
+	orders.GET("", g.listOrders)
+	orders.POST("", g.createOrder)
+	if g.stream != nil {
+		orders.GET("/events", g.stream)
+	}
+	orders.GET("/:id", g.getOrder)
+	orders.PATCH("/:id", g.updateOrder)
+	orders.DELETE("/:id", g.deleteOrder)
+}
+
+// Operations documents the routes added by Register.
+func (g *OrderGateway) Operations() []openapi.Operation {
+	tags := []string{"orders"}
+	id := openapi.Param{Name: "id", In: "path", Description: "Order ID"}
+	ops := []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/v1/orders",
+			ID:      "listOrders",
+			Summary: "List orders",
+			Tags:    tags,
+			Params: []openapi.Param{
+				{Name: "page", Description: "1-based page number", Type: int32(0)},
+				{Name: "page_size", Description: "Orders per page", Type: int32(0)},
+			},
+			Responses: map[int]any{http.StatusOK: &orderpb.ListOrdersResponse{}},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/v1/orders",
+			ID:        "createOrder",
+			Summary:   "Create an order",
+			Tags:      tags,
+			Request:   &orderpb.CreateOrderRequest{},
+			Responses: map[int]any{http.StatusCreated: &orderpb.CreateOrderResponse{}},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/v1/orders/:id",
+			ID:      "getOrder",
+			Summary: "Get an order",
+			Tags:    tags,
+			Params: []openapi.Param{
+				id,
+				{Name: "view", Description: "Also accepted without the ORDER_VIEW_ prefix, in any case", Type: orderpb.OrderView(0)},
+			},
+			Responses: map[int]any{http.StatusOK: &orderpb.OrderResponse{}},
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/v1/orders/:id",
+			ID:          "updateOrder",
+			Summary:     "Update an order",
+			Description: "order_id in the body is ignored in favour of the path.",
+			Tags:        tags,
+			Params:      []openapi.Param{id},
+			Request:     &orderpb.UpdateOrderRequest{},
+			Responses:   map[int]any{http.StatusOK: &orderpb.UpdateOrderResponse{}},
+		},
+		{
+			Method:    http.MethodDelete,
+			Path:      "/v1/orders/:id",
+			ID:        "deleteOrder",
+			Summary:   "Delete an order",
+			Tags:      tags,
+			Params:    []openapi.Param{id},
+			Responses: map[int]any{http.StatusOK: &orderpb.DeleteOrderResponse{}},
+		},
+	}
+	if g.stream != nil {
+		ops = append(ops, openapi.Operation{
+			Method:      http.MethodGet,
+			Path:        "/v1/orders/events",
+			ID:          "streamOrderEvents",
+			Summary:     "Stream order changes",
+			Description: "Server-sent events of type order.created, order.updated and order.deleted with an OrderEvent as data. Reconnect with Last-Event-ID to resume; a reset event means events were missed.",
+			Tags:        tags,
+			Params: []openapi.Param{
+				{Name: "Last-Event-ID", In: "header", Description: "ID of the last event received"},
+			},
+			Responses:   map[int]any{http.StatusOK: ""},
+			ContentType: "text/event-stream",
+		})
+	}
+	return ops
+}
+
+type listOrdersQuery struct {
+	Page     int32 `form:"page" validate:"gte=0"`
+	PageSize int32 `form:"page_size" validate:"gte=0"`
+}
+
+func (g *OrderGateway) listOrders(c *gin.Context) {
+	q, ok := bind.Bind[listOrdersQuery](c)
+	if !ok {
+		return
+	}
+	req := &orderpb.ListOrdersRequest{Page: q.Page, PageSize: q.PageSize}
+
+	ctx, cancel := g.context(c)
+	defer cancel()
+
+	resp, err := g.client.ListOrders(ctx, req)
+	writeProto(c, http.StatusOK, resp, err)
+}
+
+func (g *OrderGateway) createOrder(c *gin.Context) {
+	req := &orderpb.CreateOrderRequest{}
+	if err := readProto(c, req); err != nil {
+		problem.Error(c, err)
+		return
+	}
+
+	ctx, cancel := g.context(c)
+	defer cancel()
+
+	resp, err := g.client.CreateOrder(ctx, req)
+	writeProto(c, http.StatusCreated, resp, err)
+	if err == nil {
+		g.publish(OrderCreated, req.OrderId)
+	}
+}
+
+func (g *OrderGateway) getOrder(c *gin.Context) {
+	req := &orderpb.OrderRequest{OrderId: c.Param("id")}
+	if v := c.Query("view"); v != "" {
+		view, ok := orderpb.OrderView_value[strings.ToUpper(v)]
+		if !ok {
+			view, ok = orderpb.OrderView_value["ORDER_VIEW_"+strings.ToUpper(v)]
+		}
+		if !ok {
+			problem.Error(c, status.Errorf(codes.InvalidArgument, "invalid view %q", v))
+			return
+		}
+		req.View = orderpb.OrderView(view)
+	}
+
+	ctx, cancel := g.context(c)
+	defer cancel()
+
+	resp, err := g.client.GetOrder(ctx, req)
+	writeProto(c, http.StatusOK, resp, err)
+}
+
+func (g *OrderGateway) updateOrder(c *gin.Context) {
+	req := &orderpb.UpdateOrderRequest{}
+	if err := readProto(c, req); err != nil {
+		problem.Error(c, err)
+		return
+	}
+	req.OrderId = c.Param("id")
+
+	ctx, cancel := g.context(c)
+	defer cancel()
+
+	resp, err := g.client.UpdateOrder(ctx, req)
+	writeProto(c, http.StatusOK, resp, err)
+	if err == nil {
+		g.publish(OrderUpdated, req.OrderId)
+	}
+}
+
+func (g *OrderGateway) deleteOrder(c *gin.Context) {
+	ctx, cancel := g.context(c)
+	defer cancel()
+
+	id := c.Param("id")
+	resp, err := g.client.DeleteOrder(ctx, &orderpb.DeleteOrderRequest{OrderId: id})
+	writeProto(c, http.StatusOK, resp, err)
+	if err == nil {
+		g.publish(OrderDeleted, id)
+	}
+}
+
+func (g *OrderGateway) context(c *gin.Context) (context.Context, context.CancelFunc) {
+	if g.timeout <= 0 {
+		return context.WithCancel(c.Request.Context())
+	}
+	return context.WithTimeout(c.Request.Context(), g.timeout)
+}
+
+func readProto(c *gin.Context, m proto.Message) error {
+	// The bodylimit middleware caps the body.
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return tooLarge
+		}
+		return status.Errorf(codes.InvalidArgument, "read body: %v", err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(body, m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+func writeProto(c *gin.Context, code int, m proto.Message, err error) {
+	if err != nil {
+		problem.Error(c, err)
+		return
+	}
+	body, err := protojson.Marshal(m)
+	if err != nil {
+		problem.Error(c, status.Errorf(codes.Internal, "marshal response: %v", err))
+		return
+	}
+	c.Data(code, "application/json", body)
+}
@@ -2,102 +2,342 @@ package server
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
 	"strconv"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/go-gin/health"
 	"github.com/braden0236/playground/pkg/go-gin/metric"
+	"github.com/braden0236/playground/pkg/go-gin/problem"
+	"github.com/braden0236/playground/pkg/logging"
+	"github.com/braden0236/playground/pkg/requestid"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	engine *gin.Engine
-	http   *http.Server
+	engine  *gin.Engine
+	modules []Module
+	hooks   []ShutdownHook
+	health  *health.Health
+
+	onShutdown []func()
+
+	mu       sync.Mutex
+	started  []bool // by module index
+	http     *http.Server
+	redirect *http.Server
 }
 
-func NewServer(m *metric.Metrics) *Server {
+func NewServer(m *metric.Metrics, opts ...Option) *Server {
+
+	o := &options{
+		middleware: make(map[MiddlewarePosition][]gin.HandlerFunc),
+		health:     health.New(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
 
 	gin.DisableConsoleColor()
 	gin.SetMode(gin.ReleaseMode)
 
 	r := gin.New()
 
-	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{
-		SkipPaths: []string{"/healthz", "/metrics"},
-	}))
+	// gin trusts every peer's X-Forwarded-For unless told otherwise, which
+	// would let clients pick the IP they are rate limited by. It only fails
+	// on invalid input and then trusts no one.
+	if err := r.SetTrustedProxies(o.trustedProxies); err != nil {
+		slog.Error("invalid trusted proxies, trusting none", "error", err)
+	}
+
+	r.Use(requestid.Middleware())
+
+	r.Use(o.middleware[BeforeRecovery]...)
+
+	r.Use(logging.Middleware("/healthz", "/livez", "/readyz", "/metrics"))
 
-	r.Use(gin.Recovery())
+	r.Use(recovery())
+
+	r.Use(o.middleware[BeforeMetrics]...)
 
 	r.Use(m.Middleware())
 
+	r.Use(o.middleware[AfterMetrics]...)
+
+	r.HandleMethodNotAllowed = true
+
 	r.NoRoute(func(ctx *gin.Context) {
-		ctx.JSON(http.StatusNotFound, gin.H{"message": "route not found"})
+		problem.Write(ctx, problem.NotFound("no route for "+ctx.Request.URL.Path))
 	})
 
+	// gin sets the Allow header before calling NoMethod.
 	r.NoMethod(func(ctx *gin.Context) {
-		ctx.JSON(http.StatusNotFound, gin.H{"message": "method not found"})
+		problem.Write(ctx, problem.Newf(http.StatusMethodNotAllowed,
+			"method %s is not allowed for %s", ctx.Request.Method, ctx.Request.URL.Path))
 	})
 
-	r.GET("/healthz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"message": "healthy"})
-	})
+	r.GET("/healthz", o.health.LiveHandler())
+	r.GET("/livez", o.health.LiveHandler())
+	r.GET("/readyz", o.health.ReadyHandler())
 
 	r.GET("/metrics", m.Handler())
 
+	for _, mod := range o.modules {
+		mod.Register(r)
+	}
+
+	if o.openapi != nil {
+		mountOpenAPI(r, o.openapi, o.modules, o.swaggerUI)
+	}
+
 	return &Server{
-		engine: r,
+		engine:  r,
+		modules: o.modules,
+		hooks:   o.hooks,
+		health:  o.health,
+
+		onShutdown: o.onShutdown,
+		started:    make([]bool, len(o.modules)),
+	}
+}
+
+// Engine returns the underlying gin engine for callers that need more than
+// the Module API offers.
+func (s *Server) Engine() *gin.Engine {
+	return s.engine
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.engine.ServeHTTP(w, r)
+}
+
+// Start runs the start hooks of all modules. Run calls it; callers serving
+// Engine on their own listener should call it themselves.
+func (s *Server) Start(ctx context.Context) error {
+	for i, mod := range s.modules {
+		starter, ok := mod.(Starter)
+		if !ok {
+			continue
+		}
+		if err := starter.Start(ctx); err != nil {
+			return errors.Join(fmt.Errorf("start %T: %w", mod, err), s.stopStarted(ctx, i))
+		}
+		s.mu.Lock()
+		s.started[i] = true
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// stopStarted stops the started modules before index end, last first, and
+// marks them stopped so shutdown leaves them alone.
+func (s *Server) stopStarted(ctx context.Context, end int) error {
+	var errs []error
+	for i := end - 1; i >= 0; i-- {
+		s.mu.Lock()
+		started := s.started[i]
+		s.started[i] = false
+		s.mu.Unlock()
+
+		stopper, ok := s.modules[i].(Stopper)
+		if !started || !ok {
+			continue
+		}
+		if err := stopper.Stop(ctx); err != nil {
+			slog.Error("stop module failed", "module", fmt.Sprintf("%T", s.modules[i]), "error", err)
+			errs = append(errs, fmt.Errorf("stop %T: %w", s.modules[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownHooks returns the hooks to run, leaving out those of modules that
+// have a start hook but are not running.
+func (s *Server) shutdownHooks() []ShutdownHook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hooks := make([]ShutdownHook, 0, len(s.hooks))
+	for _, h := range s.hooks {
+		if h.module > 0 {
+			if _, ok := s.modules[h.module-1].(Starter); ok && !s.started[h.module-1] {
+				continue
+			}
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks
+}
+
+// RegisterOnShutdown registers the WithOnShutdown functions with srv, for
+// callers serving the engine from an http.Server of their own.
+func (s *Server) RegisterOnShutdown(srv *http.Server) {
+	for _, fn := range s.onShutdown {
+		srv.RegisterOnShutdown(fn)
 	}
 }
 
 func (s *Server) Run(conf config.ServerConfig) error {
-	s.http = &http.Server{
-		Addr:         ":" + strconv.Itoa(conf.Port),
-		Handler:      s.engine,
-		ReadTimeout:  conf.ReadTimeout,
-		WriteTimeout: conf.WriteTimeout,
-		IdleTimeout:  conf.IdleTimeout,
+	if err := s.Start(context.Background()); err != nil {
+		return err
 	}
-	log.Printf("Starting server on port %d", conf.Port)
 
-	err := s.http.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
+	srv := &http.Server{
+		Addr:              ":" + strconv.Itoa(conf.Port),
+		Handler:           s.engine,
+		ReadTimeout:       conf.ReadTimeout,
+		ReadHeaderTimeout: conf.ReadHeaderTimeout,
+		WriteTimeout:      conf.WriteTimeout,
+		IdleTimeout:       conf.IdleTimeout,
+		MaxHeaderBytes:    conf.MaxHeaderBytes,
+	}
+	s.RegisterOnShutdown(srv)
+
+	lis, err := listen(srv.Addr, conf.MaxConnections)
+	if err != nil {
+		return err
+	}
+
+	if !conf.TLS.Enabled() {
+		s.mu.Lock()
+		s.http = srv
+		s.mu.Unlock()
+
+		slog.Info("starting server", "port", conf.Port, "max_connections", conf.MaxConnections)
+		return ignoreServerClosed(srv.Serve(lis))
+	}
+
+	tlsConfig, err := buildTLSConfig(conf.TLS)
+	if err != nil {
+		lis.Close()
 		return err
 	}
+	srv.TLSConfig = tlsConfig
+
+	var redirect *http.Server
+	if conf.TLS.RedirectPort != 0 {
+		redirect = &http.Server{
+			Addr:              ":" + strconv.Itoa(conf.TLS.RedirectPort),
+			Handler:           redirectHandler(conf.Port),
+			ReadTimeout:       conf.ReadTimeout,
+			ReadHeaderTimeout: conf.ReadHeaderTimeout,
+			WriteTimeout:      conf.WriteTimeout,
+			IdleTimeout:       conf.IdleTimeout,
+			MaxHeaderBytes:    conf.MaxHeaderBytes,
+		}
+	}
+
+	s.mu.Lock()
+	s.http = srv
+	s.redirect = redirect
+	s.mu.Unlock()
+
+	errCh := make(chan error, 2)
+	if redirect != nil {
+		go func() {
+			slog.Info("redirecting HTTP to HTTPS", "port", conf.TLS.RedirectPort)
+			errCh <- ignoreServerClosed(redirect.ListenAndServe())
+		}()
+	}
+	go func() {
+		slog.Info("starting TLS server", "port", conf.Port, "client_auth", conf.TLS.ClientAuth, "max_connections", conf.MaxConnections)
+		errCh <- ignoreServerClosed(srv.ServeTLS(lis, "", ""))
+	}()
+
+	// Whichever listener stops first ends Run; a failing redirect listener
+	// is as fatal as the main one.
+	return <-errCh
+}
+
+// listen opens addr, capped at maxConns concurrent connections when
+// positive.
+func listen(addr string, maxConns int) (net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if maxConns > 0 {
+		lis = LimitListener(lis, maxConns)
+	}
+	return lis, nil
+}
 
+func ignoreServerClosed(err error) error {
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
 	return nil
 }
 
-func (s *Server) WaitForShutdown(cleanup func()) error {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Print("Shutdown signal received. Stopping server...")
+// SetNotReady fails /readyz from now on, e.g. while draining a combined
+// server that serves Engine on its own listener.
+func (s *Server) SetNotReady() {
+	s.health.SetNotReady()
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// GracefulShutdown marks the server not ready, keeps serving for the drain
+// delay so load balancers can stop routing to it, then shuts down within
+// the configured deadline.
+func (s *Server) GracefulShutdown(conf config.ServerConfig) error {
+	s.SetNotReady()
 
-	if cleanup != nil {
-		cleanup()
+	if conf.DrainDelay > 0 {
+		slog.Info("draining before shutdown", "delay", conf.DrainDelay)
+		time.Sleep(conf.DrainDelay)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), conf.ShutdownTimeout)
+	defer cancel()
+
 	if err := s.Shutdown(ctx); err != nil {
-		log.Printf("Shutdown failed: %v", err)
+		slog.Error("shutdown failed", "error", err)
 		return err
 	}
 
-	log.Print("Server stopped gracefully.")
+	slog.Info("server stopped gracefully")
 	return nil
 }
 
+// Shutdown stops the HTTP server and then runs the shutdown hooks in order.
+// Hook failures don't stop the chain; all errors are returned joined.
 func (s *Server) Shutdown(ctx context.Context) error {
-	if s.http == nil {
-		return nil
+	s.health.SetNotReady()
+
+	s.mu.Lock()
+	srv, redirect := s.http, s.redirect
+	s.mu.Unlock()
+
+	var errs []error
+	if redirect != nil {
+		if err := redirect.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("redirect server: %w", err))
+		}
+	}
+	if srv != nil {
+		if err := srv.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http server: %w", err))
+		}
 	}
-	return s.http.Shutdown(ctx)
+
+	errs = append(errs, runShutdownHooks(ctx, s.shutdownHooks())...)
+
+	return errors.Join(errs...)
+}
+
+func (s *Server) RunFunc(conf config.ServerConfig) (func() error, func(error)) {
+	return func() error {
+			return s.Run(conf)
+		}, func(err error) {
+			if err != nil {
+				slog.Error("server interrupted", "error", err)
+			}
+			_ = s.GracefulShutdown(conf)
+		}
 }
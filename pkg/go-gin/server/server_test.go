@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/go-gin/health"
+	"github.com/braden0236/playground/pkg/go-gin/metric"
+	"github.com/braden0236/playground/pkg/go-gin/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProbes(t *testing.T) {
+	var dbErr error
+	s := NewServer(metric.NewMetrics(),
+		WithChecker(health.NewChecker("db", func(context.Context) error { return dbErr }), health.WithCacheTTL(0)),
+	)
+	probe := func(path string) int {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	steps := []struct {
+		name     string
+		dbErr    error
+		notReady bool
+		want     map[string]int
+	}{
+		{"healthy", nil, false, map[string]int{"/healthz": 200, "/livez": 200, "/readyz": 200}},
+		{"checker failing", errors.New("down"), false, map[string]int{"/healthz": 200, "/livez": 200, "/readyz": 503}},
+		{"checker recovered", nil, false, map[string]int{"/healthz": 200, "/livez": 200, "/readyz": 200}},
+		{"shutting down", nil, true, map[string]int{"/healthz": 200, "/livez": 200, "/readyz": 503}},
+	}
+	for _, st := range steps {
+		dbErr = st.dbErr
+		if st.notReady {
+			if err := s.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown() error = %v", err)
+			}
+		}
+		for path, want := range st.want {
+			if got := probe(path); got != want {
+				t.Errorf("%s: GET %s = %d, want %d", st.name, path, got, want)
+			}
+		}
+	}
+}
+
+func TestTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		want    []int
+	}{
+		// Each request claims another client; only the peer address counts.
+		{"none trusted", nil, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}},
+		{"other proxy trusted", []string{"10.0.0.0/8"}, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}},
+		{"peer trusted", []string{"192.0.2.1"}, []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := ratelimit.New(ratelimit.Limit{Rate: 0.001, Burst: 1}, ratelimit.WithRegisterer(prometheus.NewRegistry()))
+			s := NewServer(metric.NewMetrics(),
+				WithTrustedProxies(tt.proxies...),
+				WithMiddleware(AfterMetrics, limiter.Middleware()),
+				WithRoutes("", func(r gin.IRouter) {
+					r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+				}),
+			)
+			for i, want := range tt.want {
+				req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+				w := httptest.NewRecorder()
+				s.ServeHTTP(w, req)
+				if w.Code != want {
+					t.Fatalf("request %d = %d, want %d", i+1, w.Code, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ShutdownHook struct {
+	Name    string
+	Timeout time.Duration
+	Fn      func(ctx context.Context) error
+
+	module int // 1-based index of the module the hook stops, 0 for none
+}
+
+// WaitForShutdown returns a run.Group actor that exits once ctx is done,
+// e.g. when signal.NotifyContext sees SIGTERM.
+func WaitForShutdown(ctx context.Context, stop context.CancelFunc) (func() error, func(error)) {
+	return func() error {
+			<-ctx.Done()
+			slog.Info("shutdown signal received, stopping server")
+			return nil
+		}, func(error) {
+			stop()
+		}
+}
+
+func runShutdownHooks(ctx context.Context, hooks []ShutdownHook) []error {
+	var errs []error
+	for _, h := range hooks {
+		if err := runShutdownHook(ctx, h); err != nil {
+			slog.Error("shutdown hook failed", "hook", h.Name, "error", err)
+			errs = append(errs, fmt.Errorf("shutdown hook %s: %w", h.Name, err))
+		}
+	}
+	return errs
+}
+
+// runShutdownHook runs h in the caller's goroutine, so a hook that outlives
+// its timeout holds up the chain rather than leaking.
+func runShutdownHook(ctx context.Context, h ShutdownHook) error {
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+
+	return h.Fn(ctx)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/braden0236/playground/pkg/go-gin/config"
+	"github.com/braden0236/playground/pkg/tlsutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// buildTLSConfig turns conf into a server tls.Config.
+func buildTLSConfig(conf config.TLSConfig) (*tls.Config, error) {
+	clientAuth, err := tlsutil.ParseClientAuth(conf.ClientAuth)
+	if err != nil {
+		return nil, err
+	}
+	minVersion, err := tlsutil.ParseVersion(conf.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	return tlsutil.BuildServerConfig(conf, tlsutil.WithClientAuth(clientAuth), tlsutil.WithMinVersion(minVersion))
+}
+
+// redirectHandler sends plaintext requests to the same host on httpsPort.
+func redirectHandler(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// ClientIdentity describes the certificate a client presented over TLS.
+type ClientIdentity struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	EmailAddress []string
+	SerialNumber string
+	Fingerprint  string // hex SHA-256 of the DER certificate
+	Verified     bool   // chain was verified against the configured CAs
+}
+
+// ClientCertificate returns the leaf certificate presented by the client,
+// if any.
+func ClientCertificate(c *gin.Context) *x509.Certificate {
+	if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return c.Request.TLS.PeerCertificates[0]
+}
+
+// GetClientIdentity returns the identity of the client certificate, if the
+// client presented one.
+func GetClientIdentity(c *gin.Context) (ClientIdentity, bool) {
+	cert := ClientCertificate(c)
+	if cert == nil {
+		return ClientIdentity{}, false
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	return ClientIdentity{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		DNSNames:     cert.DNSNames,
+		EmailAddress: cert.EmailAddresses,
+		SerialNumber: cert.SerialNumber.String(),
+		Fingerprint:  hex.EncodeToString(sum[:]),
+		Verified:     len(c.Request.TLS.VerifiedChains) > 0,
+	}, true
+}
@@ -0,0 +1,204 @@
+package sse
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const DefaultHistory = 1024
+
+// Event is one message of the stream. IDs increase by one per event and
+// start over when the process restarts.
+type Event struct {
+	ID   uint64
+	Type string
+	Data []byte
+}
+
+type BrokerOption func(*Broker)
+
+// WithHistory sets how many recent events are kept for clients resuming
+// with Last-Event-ID.
+func WithHistory(n int) BrokerOption {
+	return func(b *Broker) {
+		if n > 0 {
+			b.size = n
+		}
+	}
+}
+
+// WithRegisterer registers the broker's metrics with reg. Without it they go
+// to a registry of the broker's own and aren't exported.
+func WithRegisterer(reg prometheus.Registerer) BrokerOption {
+	return func(b *Broker) {
+		b.registerer = reg
+	}
+}
+
+// Broker fans events out to subscribers. Publishing never blocks: a
+// subscriber that falls a full buffer behind is dropped, and its client
+// catches up from history when it reconnects.
+type Broker struct {
+	size       int
+	registerer prometheus.Registerer
+	clients    prometheus.Gauge
+	dropped    prometheus.Counter
+
+	mu      sync.Mutex
+	lastID  uint64
+	history []Event // ring of the last size events
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+func NewBroker(opts ...BrokerOption) *Broker {
+	b := &Broker{
+		size: DefaultHistory,
+		subs: make(map[*Subscription]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.registerer == nil {
+		b.registerer = prometheus.NewRegistry()
+	}
+
+	b.clients = promauto.With(b.registerer).NewGauge(prometheus.GaugeOpts{
+		Name: "sse_clients",
+		Help: "Connected server-sent event clients.",
+	})
+	b.dropped = promauto.With(b.registerer).NewCounter(prometheus.CounterOpts{
+		Name: "sse_clients_dropped_total",
+		Help: "Server-sent event clients disconnected for falling behind.",
+	})
+	return b
+}
+
+// Publish sends an event of type typ with data encoded as JSON.
+func (b *Broker) Publish(typ string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+
+	b.lastID++
+	ev := Event{ID: b.lastID, Type: typ, Data: body}
+	if len(b.history) < b.size {
+		b.history = append(b.history, ev)
+	} else {
+		b.history[int((ev.ID-1)%uint64(b.size))] = ev
+	}
+
+	for sub := range b.subs {
+		select {
+		case sub.events <- ev:
+		default:
+			b.drop(sub)
+			b.dropped.Inc()
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber for events published from now on, with
+// room for buffer undelivered events.
+func (b *Broker) Subscribe(buffer int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(buffer)
+}
+
+// SubscribeAfter is Subscribe for a client that last saw lastID. It also
+// returns the retained events after lastID; ok is false if some of them
+// were already evicted or lastID is from before a restart.
+func (b *Broker) SubscribeAfter(lastID uint64, buffer int) (sub *Subscription, replay []Event, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = b.subscribe(buffer)
+	oldest := b.lastID - uint64(len(b.history)) + 1
+	switch {
+	case lastID > b.lastID:
+		return sub, nil, false
+	case lastID+1 < oldest:
+		return sub, nil, false
+	}
+	for id := lastID + 1; id <= b.lastID; id++ {
+		replay = append(replay, b.history[int((id-1)%uint64(b.size))])
+	}
+	return sub, replay, true
+}
+
+// LastID returns the ID of the latest event, 0 before the first.
+func (b *Broker) LastID() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastID
+}
+
+// Close ends all subscriptions; later ones end immediately.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		b.drop(sub)
+	}
+}
+
+func (b *Broker) subscribe(buffer int) *Subscription {
+	sub := &Subscription{
+		broker: b,
+		events: make(chan Event, buffer),
+		done:   make(chan struct{}),
+	}
+	if b.closed {
+		close(sub.done)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	b.clients.Inc()
+	return sub
+}
+
+// drop must be called with mu held.
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.done)
+	b.clients.Dec()
+}
+
+type Subscription struct {
+	broker *Broker
+	events chan Event
+	done   chan struct{}
+}
+
+// Events delivers published events in order.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed when the subscription ends: the subscriber fell behind,
+// the broker closed or Close was called.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
@@ -0,0 +1,167 @@
+package sse
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultHeartbeat = 15 * time.Second
+	DefaultBuffer    = 64
+	DefaultRetry     = 3 * time.Second
+)
+
+// ResetEvent tells a client that events it missed are gone and it should
+// reload its state before relying on the stream.
+const ResetEvent = "reset"
+
+type Option func(*handler)
+
+// WithHeartbeat sets how often a comment is sent on an idle stream, so
+// proxies keep the connection open.
+func WithHeartbeat(d time.Duration) Option {
+	return func(h *handler) {
+		if d > 0 {
+			h.heartbeat = d
+		}
+	}
+}
+
+// WithBuffer sets how many events may be pending for a connection before it
+// is dropped.
+func WithBuffer(n int) Option {
+	return func(h *handler) {
+		if n > 0 {
+			h.buffer = n
+		}
+	}
+}
+
+// WithRetry sets the reconnection delay suggested to clients.
+func WithRetry(d time.Duration) Option {
+	return func(h *handler) {
+		if d > 0 {
+			h.retry = d
+		}
+	}
+}
+
+type handler struct {
+	broker    *Broker
+	heartbeat time.Duration
+	buffer    int
+	retry     time.Duration
+}
+
+// Handler streams the broker's events as text/event-stream. A client that
+// reconnects with Last-Event-ID gets the events it missed, or a reset event
+// when they are no longer retained.
+func Handler(b *Broker, opts ...Option) gin.HandlerFunc {
+	h := &handler{
+		broker:    b,
+		heartbeat: DefaultHeartbeat,
+		buffer:    DefaultBuffer,
+		retry:     DefaultRetry,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h.serve
+}
+
+func (h *handler) serve(c *gin.Context) {
+	var (
+		sub    *Subscription
+		replay []Event
+		ok     = true
+	)
+	if v := c.GetHeader("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			sub, ok = h.broker.Subscribe(h.buffer), false
+		} else {
+			sub, replay, ok = h.broker.SubscribeAfter(id, h.buffer)
+		}
+	} else {
+		sub = h.broker.Subscribe(h.buffer)
+	}
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	rc := http.NewResponseController(c.Writer)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "retry: %d\n\n", h.retry.Milliseconds())
+	if !ok {
+		writeEvent(&buf, Event{ID: h.broker.LastID(), Type: ResetEvent, Data: []byte("{}")})
+	}
+	for _, ev := range replay {
+		writeEvent(&buf, ev)
+	}
+	if !h.send(c, rc, buf.Bytes()) {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		buf.Reset()
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-sub.Done():
+			return
+		case ev := <-sub.Events():
+			writeEvent(&buf, ev)
+			ticker.Reset(h.heartbeat)
+		case <-ticker.C:
+			buf.WriteString(": heartbeat\n\n")
+		}
+		if !h.send(c, rc, buf.Bytes()) {
+			return
+		}
+	}
+}
+
+// send writes and flushes b. The server's write timeout covers the whole
+// response and would cut the stream, so each write gets its own deadline of
+// one heartbeat instead: a client that can't take an event in that time is
+// dropped rather than holding the connection open.
+func (h *handler) send(c *gin.Context, rc *http.ResponseController, b []byte) bool {
+	err := rc.SetWriteDeadline(time.Now().Add(h.heartbeat))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return false
+	}
+	if _, err := c.Writer.Write(b); err != nil {
+		return false
+	}
+	if err := rc.Flush(); err != nil {
+		return false
+	}
+	return true
+}
+
+func writeEvent(buf *bytes.Buffer, ev Event) {
+	fmt.Fprintf(buf, "id: %d\n", ev.ID)
+	if ev.Type != "" {
+		fmt.Fprintf(buf, "event: %s\n", ev.Type)
+	}
+	for line := range bytes.Lines(ev.Data) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimRight(line, "\r\n"))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+}
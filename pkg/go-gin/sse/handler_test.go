@@ -0,0 +1,177 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestBroker(t *testing.T, opts ...BrokerOption) *Broker {
+	t.Helper()
+	b := NewBroker(opts...)
+	t.Cleanup(b.Close)
+	return b
+}
+
+// stream opens the event stream and returns a function reading one frame,
+// i.e. everything up to the next blank line.
+func stream(t *testing.T, srv *httptest.Server, lastID string) func() string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	return func() string {
+		t.Helper()
+		var frame strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading stream: %v (got %q)", err, frame.String())
+			}
+			if line == "\n" {
+				return frame.String()
+			}
+			frame.WriteString(line)
+		}
+	}
+}
+
+func newTestServer(t *testing.T, b *Broker, writeTimeout time.Duration, opts ...Option) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/events", Handler(b, opts...))
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = writeTimeout
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHandlerDeliversEvents(t *testing.T) {
+	b := newTestBroker(t)
+	srv := newTestServer(t, b, 0, WithRetry(time.Second))
+	next := stream(t, srv, "")
+
+	if got := next(); got != "retry: 1000\n" {
+		t.Fatalf("first frame = %q", got)
+	}
+	// Events must be flushed as they are published, not when the response
+	// ends.
+	if err := b.Publish("order", map[string]string{"id": "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := next(), "id: 1\nevent: order\ndata: {\"id\":\"1\"}\n"; got != want {
+		t.Fatalf("event frame = %q, want %q", got, want)
+	}
+}
+
+func TestHandlerResume(t *testing.T) {
+	b := newTestBroker(t, WithHistory(2))
+	srv := newTestServer(t, b, 0)
+	for i := range 3 {
+		if err := b.Publish("order", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		lastID string
+		want   []string
+	}{
+		{name: "retained", lastID: "1", want: []string{"id: 2\nevent: order\ndata: 1\n", "id: 3\nevent: order\ndata: 2\n"}},
+		{name: "up to date", lastID: "3"},
+		{name: "evicted", lastID: "0", want: []string{"id: 3\nevent: reset\ndata: {}\n"}},
+		{name: "from before a restart", lastID: "9", want: []string{"id: 3\nevent: reset\ndata: {}\n"}},
+		{name: "malformed", lastID: "abc", want: []string{"id: 3\nevent: reset\ndata: {}\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := stream(t, srv, tt.lastID)
+			next() // retry
+			for _, want := range tt.want {
+				if got := next(); got != want {
+					t.Fatalf("frame = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerOutlivesWriteTimeout(t *testing.T) {
+	b := newTestBroker(t)
+	srv := newTestServer(t, b, 100*time.Millisecond, WithHeartbeat(50*time.Millisecond))
+	next := stream(t, srv, "")
+	next() // retry
+
+	// Heartbeats keep arriving well past the server's write timeout, since
+	// every write gets a fresh deadline.
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if got := next(); got != ": heartbeat\n" {
+			t.Fatalf("frame = %q, want a heartbeat", got)
+		}
+	}
+	if err := b.Publish("order", 1); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		got := next()
+		if got == ": heartbeat\n" {
+			continue
+		}
+		if want := "id: 1\nevent: order\ndata: 1\n"; got != want {
+			t.Fatalf("frame = %q, want %q", got, want)
+		}
+		return
+	}
+}
+
+func TestHandlerEndsWhenBrokerCloses(t *testing.T) {
+	b := newTestBroker(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/events", Handler(b))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/events", nil)
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		r.ServeHTTP(w, req)
+		close(done)
+	}()
+
+	// The recorder doesn't support write deadlines, which must not end the
+	// stream by itself.
+	b.Close()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("handler did not return after the broker closed")
+	}
+}
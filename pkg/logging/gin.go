@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware stores a request-scoped logger in the request context and
+// writes one access log line per request. Paths in skipPaths are served but
+// not logged.
+func Middleware(skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		logger := slog.Default().With(
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"peer", c.ClientIP(),
+		)
+		if id := requestid.Get(c); id != "" {
+			logger = logger.With("request_id", id)
+		}
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), logger))
+
+		c.Next()
+
+		if _, ok := skip[c.Request.URL.Path]; ok {
+			return
+		}
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"route", c.FullPath(),
+			"status", status,
+			"latency", time.Since(start),
+			"bytes", c.Writer.Size(),
+			"user_agent", c.Request.UserAgent(),
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate).String(); errs != "" {
+			attrs = append(attrs, "errors", errs)
+		}
+
+		lvl := slog.LevelInfo
+		switch {
+		case status >= 500:
+			lvl = slog.LevelError
+		case status >= 400:
+			lvl = slog.LevelWarn
+		}
+		logger.Log(c.Request.Context(), lvl, "http request", attrs...)
+	}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	buf := captureDefault(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(requestid.Middleware(), Middleware("/healthz"))
+	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/orders/:id", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("handler")
+		switch c.Param("id") {
+		case "missing":
+			c.Status(http.StatusNotFound)
+		case "broken":
+			_ = c.Error(errors.New("db down"))
+			c.Status(http.StatusInternalServerError)
+		default:
+			c.String(http.StatusOK, "ok")
+		}
+	})
+
+	tests := []struct {
+		path       string
+		wantLevel  string
+		wantStatus float64
+		wantErrors string
+	}{
+		{"/orders/1", "INFO", 200, ""},
+		{"/orders/missing", "WARN", 404, ""},
+		{"/orders/broken", "ERROR", 500, "Error #01: db down\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(requestid.Header, "req-7")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			logs := entries(t, buf)
+			if len(logs) != 2 {
+				t.Fatalf("got %d log lines, want the handler's and the access log", len(logs))
+			}
+			handler, access := logs[0], logs[1]
+			if handler["request_id"] != "req-7" || handler["path"] != tt.path {
+				t.Errorf("handler log = %v, want it tagged with the request", handler)
+			}
+			want := map[string]any{
+				"msg":        "http request",
+				"level":      tt.wantLevel,
+				"status":     tt.wantStatus,
+				"route":      "/orders/:id",
+				"method":     http.MethodGet,
+				"request_id": "req-7",
+			}
+			for k, v := range want {
+				if access[k] != v {
+					t.Errorf("access log %s = %v, want %v", k, access[k], v)
+				}
+			}
+			if got, _ := access["errors"].(string); got != tt.wantErrors {
+				t.Errorf("access log errors = %q, want %q", got, tt.wantErrors)
+			}
+		})
+	}
+
+	buf.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if buf.Len() != 0 {
+		t.Fatalf("skipped path logged: %s", buf)
+	}
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func requestLogger(ctx context.Context, method string) *slog.Logger {
+	logger := slog.Default().With("grpc_method", method)
+	if p, ok := peer.FromContext(ctx); ok {
+		logger = logger.With("peer", p.Addr.String())
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		logger = logger.With("request_id", id)
+	}
+	return logger
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+
+	lvl := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.FailedPrecondition:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		lvl = slog.LevelError
+	default:
+		lvl = slog.LevelWarn
+	}
+
+	attrs := []any{"code", code.String(), "latency", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	logger.Log(ctx, lvl, "grpc request", attrs...)
+}
+
+// UnaryServerInterceptor stores a request-scoped logger in the handler
+// context and writes an access log line per call. It must run after the
+// request ID interceptor to pick up the ID.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		logger := requestLogger(ctx, info.FullMethod)
+		ctx = NewContext(ctx, logger)
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, start, err)
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		logger := requestLogger(ss.Context(), info.FullMethod)
+		ctx := NewContext(ss.Context(), logger)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, start, err)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
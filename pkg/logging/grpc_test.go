@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/requestid"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testStream) Context() context.Context { return s.ctx }
+
+func TestServerInterceptors(t *testing.T) {
+	buf := captureDefault(t)
+	const method = "/orders.OrderService/GetOrder"
+	ctx := peer.NewContext(requestid.NewContext(context.Background(), "req-9"),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+	unary := UnaryServerInterceptor()
+	stream := StreamServerInterceptor()
+	calls := map[string]func(err error) error{
+		"unary": func(err error) error {
+			_, got := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ any) (any, error) {
+				FromContext(ctx).Info("handler")
+				return nil, err
+			})
+			return got
+		},
+		"stream": func(err error) error {
+			return stream(nil, testStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method}, func(_ any, ss grpc.ServerStream) error {
+				FromContext(ss.Context()).Info("handler")
+				return err
+			})
+		},
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantLevel string
+		wantCode  string
+	}{
+		{"ok", nil, "INFO", "OK"},
+		{"client error", status.Error(codes.NotFound, "no order"), "INFO", "NotFound"},
+		{"unavailable", status.Error(codes.Unavailable, "carrier down"), "WARN", "Unavailable"},
+		{"internal", status.Error(codes.Internal, "boom"), "ERROR", "Internal"},
+	}
+	for name, call := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				buf.Reset()
+				if err := call(tt.err); err != tt.err {
+					t.Fatalf("error = %v, want %v passed through", err, tt.err)
+				}
+				logs := entries(t, buf)
+				if len(logs) != 2 {
+					t.Fatalf("got %d log lines, want the handler's and the access log", len(logs))
+				}
+				for _, e := range logs {
+					if e["request_id"] != "req-9" || e["grpc_method"] != method || e["peer"] != "10.0.0.1:5000" {
+						t.Errorf("log %v not tagged with the call", e)
+					}
+				}
+				access := logs[1]
+				if access["level"] != tt.wantLevel || access["code"] != tt.wantCode {
+					t.Errorf("access log level %v code %v, want %s %s", access["level"], access["code"], tt.wantLevel, tt.wantCode)
+				}
+				if tt.err != nil && access["error"] != status.Convert(tt.err).Message() {
+					t.Errorf("access log error = %v, want %q", access["error"], status.Convert(tt.err).Message())
+				}
+			})
+		}
+	}
+}
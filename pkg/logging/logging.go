@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/braden0236/playground/pkg/requestid"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Format string // json | text
+	Level  string // debug | info | warn | error
+}
+
+var level = new(slog.LevelVar)
+
+type contextKey struct{}
+
+// New builds a logger writing to w whose level follows SetLevel.
+func New(w io.Writer, conf Config) (*slog.Logger, error) {
+	if conf.Level != "" {
+		if err := SetLevel(conf.Level); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(conf.Format) {
+	case "", FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", conf.Format)
+	}
+}
+
+// Setup installs a logger on stderr as the slog default, which also routes
+// the standard log package through it.
+func Setup(conf Config) error {
+	logger, err := New(os.Stderr, conf)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// SetLevel changes the level of every logger built by New at runtime.
+func SetLevel(s string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("unknown log level %q", s)
+	}
+	level.Set(l)
+	return nil
+}
+
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, or the
+// default logger tagged with the request ID of ctx, if any.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/braden0236/playground/pkg/requestid"
+)
+
+// captureDefault installs a JSON default logger writing to the returned
+// buffer at debug level, restoring the previous one when t ends.
+func captureDefault(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	prev, prevLevel := slog.Default(), level.Level()
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		level.Set(prevLevel)
+	})
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{Format: FormatJSON, Level: "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.SetDefault(logger)
+	return &buf
+}
+
+// entries decodes the JSON lines written to buf.
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e map[string]any
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestNew(t *testing.T) {
+	prevLevel := level.Level()
+	t.Cleanup(func() { level.Set(prevLevel) })
+
+	tests := []struct {
+		name     string
+		conf     Config
+		wantErr  bool
+		wantLine string
+	}{
+		{"JSON by default", Config{}, false, `"msg":"hello"`},
+		{"JSON", Config{Format: "JSON"}, false, `"msg":"hello"`},
+		{"text", Config{Format: FormatText}, false, `msg=hello`},
+		{"unknown format", Config{Format: "xml"}, true, ""},
+		{"unknown level", Config{Level: "loud"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level.Set(slog.LevelInfo)
+			var buf bytes.Buffer
+			logger, err := New(&buf, tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			logger.Info("hello")
+			if !strings.Contains(buf.String(), tt.wantLine) {
+				t.Fatalf("output = %q, want it to contain %q", buf.String(), tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	prevLevel := level.Level()
+	t.Cleanup(func() { level.Set(prevLevel) })
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{Level: "info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+		debugs  bool
+	}{
+		{"debug", "debug", false, true},
+		{"WARN", "warn", false, false},
+		{"info", "info", false, false},
+		{"verbose", "info", true, false},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		if err := SetLevel(tt.in); (err != nil) != tt.wantErr {
+			t.Fatalf("SetLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got := Level(); got != tt.want {
+			t.Errorf("after SetLevel(%q): Level() = %q, want %q", tt.in, got, tt.want)
+		}
+		// Loggers built before the change follow it.
+		logger.Debug("probe")
+		if got := buf.Len() > 0; got != tt.debugs {
+			t.Errorf("after SetLevel(%q): debug logged = %v, want %v", tt.in, got, tt.debugs)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	buf := captureDefault(t)
+	scoped := slog.Default().With("scope", "request")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]any
+	}{
+		{"default", context.Background(), map[string]any{}},
+		{"request ID only", requestid.NewContext(context.Background(), "req-1"), map[string]any{"request_id": "req-1"}},
+		{"stored logger wins", NewContext(requestid.NewContext(context.Background(), "req-1"), scoped), map[string]any{"scope": "request"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			FromContext(tt.ctx).Info("hello")
+			e := entries(t, buf)[0]
+			for k, v := range tt.want {
+				if e[k] != v {
+					t.Errorf("%s = %v, want %v", k, e[k], v)
+				}
+			}
+			if _, ok := tt.want["request_id"]; !ok && e["request_id"] != nil {
+				t.Errorf("request_id = %v, want none", e["request_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package requestid
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKey is the gin context key holding the request ID.
+const ContextKey = "request_id"
+
+// Middleware adopts X-Request-ID from the request, or generates one, stores
+// it in both the gin and the request context and echoes it in the response.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := Ensure(c.GetHeader(Header))
+
+		c.Set(ContextKey, id)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), id))
+		c.Header(Header, id)
+
+		c.Next()
+	}
+}
+
+// Get returns the request ID of c.
+func Get(c *gin.Context) string {
+	return c.GetString(ContextKey)
+}
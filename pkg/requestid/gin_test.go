@@ -0,0 +1,51 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		adopt  bool
+	}{
+		{"adopts the caller's ID", "req-42", true},
+		{"generates one when missing", "", false},
+		{"replaces an invalid one", "bad id\r\nX-Forged: 1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(Middleware())
+			var fromGin, fromCtx string
+			r.GET("/", func(c *gin.Context) {
+				fromGin = Get(c)
+				fromCtx = FromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(Header)
+			if tt.adopt && got != tt.header {
+				t.Fatalf("response %s = %q, want %q", Header, got, tt.header)
+			}
+			if !tt.adopt && !uuidV4.MatchString(got) {
+				t.Fatalf("response %s = %q, want a fresh UUIDv4", Header, got)
+			}
+			if fromGin != got || fromCtx != got {
+				t.Fatalf("handler saw %q in gin and %q in the request context, want %q", fromGin, fromCtx, got)
+			}
+		})
+	}
+}
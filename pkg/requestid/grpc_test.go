@@ -0,0 +1,120 @@
+package requestid
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recordingHealth records the request ID each call's handler sees.
+type recordingHealth struct {
+	grpc_health_v1.UnimplementedHealthServer
+	ids chan string
+}
+
+func (h *recordingHealth) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	h.ids <- FromContext(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *recordingHealth) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	h.ids <- FromContext(stream.Context())
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func newTestClient(t *testing.T) (grpc_health_v1.HealthClient, *recordingHealth) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor()),
+	)
+	h := &recordingHealth{ids: make(chan string, 1)}
+	grpc_health_v1.RegisterHealthServer(srv, h)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn), h
+}
+
+func TestGRPCPropagation(t *testing.T) {
+	client, h := newTestClient(t)
+
+	tests := []struct {
+		name     string
+		ctxID    string // set through NewContext, sent by the client interceptor
+		metadata string // set directly as outgoing metadata
+		adopt    string
+	}{
+		{"forwarded from the context", "req-ctx", "", "req-ctx"},
+		{"raw metadata", "", "req-md", "req-md"},
+		{"generated when missing", "", "", ""},
+		{"invalid replaced", "", "bad id", ""},
+	}
+	calls := []struct {
+		name string
+		call func(ctx context.Context, header *metadata.MD) error
+	}{
+		{"unary", func(ctx context.Context, header *metadata.MD) error {
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(header))
+			return err
+		}},
+		{"stream", func(ctx context.Context, header *metadata.MD) error {
+			stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				return err
+			}
+			if _, err := stream.Recv(); err != nil {
+				return err
+			}
+			*header, err = stream.Header()
+			return err
+		}},
+	}
+	for _, call := range calls {
+		for _, tt := range tests {
+			t.Run(call.name+"/"+tt.name, func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if tt.ctxID != "" {
+					ctx = NewContext(ctx, tt.ctxID)
+				}
+				if tt.metadata != "" {
+					ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, tt.metadata)
+				}
+
+				var header metadata.MD
+				if err := call.call(ctx, &header); err != nil {
+					t.Fatalf("call error = %v", err)
+				}
+				seen := <-h.ids
+				if tt.adopt != "" && seen != tt.adopt {
+					t.Fatalf("handler saw %q, want %q", seen, tt.adopt)
+				}
+				if tt.adopt == "" && !uuidV4.MatchString(seen) {
+					t.Fatalf("handler saw %q, want a fresh UUIDv4", seen)
+				}
+				if got := header.Get(MetadataKey); len(got) != 1 || got[0] != seen {
+					t.Fatalf("response header %s = %q, want %q", MetadataKey, got, seen)
+				}
+			})
+		}
+	}
+}
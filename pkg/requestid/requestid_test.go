@@ -0,0 +1,61 @@
+package requestid
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNew(t *testing.T) {
+	a, b := New(), New()
+	if !uuidV4.MatchString(a) {
+		t.Fatalf("New() = %q, want a UUIDv4", a)
+	}
+	if a == b {
+		t.Fatalf("New() returned %q twice", a)
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		adopt bool
+	}{
+		{"uuid", "0b0f7c2e-5d0e-4c39-9a57-3a0f5b1d1e2f", true},
+		{"opaque token", "req-42_abc.DEF", true},
+		{"max length", strings.Repeat("a", maxLength), true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxLength+1), false},
+		{"space", "req 42", false},
+		{"newline", "req\n42", false},
+		{"non-ASCII", "req-ü", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valid(tt.in); got != tt.adopt {
+				t.Errorf("Valid(%q) = %v, want %v", tt.in, got, tt.adopt)
+			}
+			got := Ensure(tt.in)
+			if tt.adopt && got != tt.in {
+				t.Errorf("Ensure(%q) = %q, want it adopted", tt.in, got)
+			}
+			if !tt.adopt && !uuidV4.MatchString(got) {
+				t.Errorf("Ensure(%q) = %q, want a fresh UUIDv4", tt.in, got)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if id := FromContext(ctx); id != "" {
+		t.Fatalf("FromContext(empty) = %q, want \"\"", id)
+	}
+	if id := FromContext(NewContext(ctx, "abc")); id != "abc" {
+		t.Fatalf("FromContext() = %q, want abc", id)
+	}
+}
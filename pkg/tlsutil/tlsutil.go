@@ -0,0 +1,147 @@
+// Package tlsutil builds the tls.Configs of the gRPC and gin servers and
+// their clients from PEM files, so both sides apply the same TLS policy.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileProvider names the PEM files of a TLS config: the certificate and key
+// to present and the CA bundle that verifies the peer.
+type FileProvider interface {
+	GetCertFile() string
+	GetKeyFile() string
+	GetCaFile() string
+}
+
+// ConfigProvider adds the name the server's certificate is verified
+// against, for clients.
+type ConfigProvider interface {
+	FileProvider
+	GetServerName() string
+}
+
+var clientAuthModes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify_if_given":    tls.VerifyClientCertIfGiven,
+	"require_and_verify": tls.RequireAndVerifyClientCert,
+}
+
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseClientAuth parses none, request, require, verify_if_given or
+// require_and_verify, in any case.
+func ParseClientAuth(s string) (tls.ClientAuthType, error) {
+	mode, ok := clientAuthModes[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS client auth mode %q", s)
+	}
+	return mode, nil
+}
+
+// ParseVersion parses a TLS version such as "1.2".
+func ParseVersion(s string) (uint16, error) {
+	v, ok := versions[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS version %q", s)
+	}
+	return v, nil
+}
+
+type ServerOption func(*tls.Config)
+
+// WithClientAuth sets whether client certificates are requested and
+// verified. The default is tls.NoClientCert.
+func WithClientAuth(mode tls.ClientAuthType) ServerOption {
+	return func(c *tls.Config) {
+		c.ClientAuth = mode
+	}
+}
+
+// WithMinVersion sets the oldest TLS version accepted. The default is 1.2.
+func WithMinVersion(v uint16) ServerOption {
+	return func(c *tls.Config) {
+		c.MinVersion = v
+	}
+}
+
+// BuildServerConfig presents the cert and key. When the client auth mode
+// verifies client certs, they are verified against the CA file, or the
+// system pool when none is set.
+func BuildServerConfig(provider FileProvider, opts ...ServerOption) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(provider.GetCertFile(), provider.GetKeyFile())
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.NoClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	for _, opt := range opts {
+		opt(tlsConfig)
+	}
+
+	if tlsConfig.ClientAuth == tls.VerifyClientCertIfGiven || tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert {
+		if provider.GetCaFile() != "" {
+			tlsConfig.ClientCAs, err = loadCertPool(provider.GetCaFile())
+		} else {
+			tlsConfig.ClientCAs, err = x509.SystemCertPool()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// BuildClientConfig verifies the server against the CA file, or the system
+// pool when none is set, and presents a client cert only if one is set.
+func BuildClientConfig(provider ConfigProvider) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: provider.GetServerName(),
+	}
+
+	if provider.GetCaFile() != "" {
+		pool, err := loadCertPool(provider.GetCaFile())
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if provider.GetCertFile() != "" && provider.GetKeyFile() != "" {
+		cert, err := tls.LoadX509KeyPair(provider.GetCertFile(), provider.GetKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	caBytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
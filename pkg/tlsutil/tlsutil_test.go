@@ -0,0 +1,242 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type files struct {
+	cert, key, ca, serverName string
+}
+
+func (f files) GetCertFile() string   { return f.cert }
+func (f files) GetKeyFile() string    { return f.key }
+func (f files) GetCaFile() string     { return f.ca }
+func (f files) GetServerName() string { return f.serverName }
+
+// writeCert writes a self-signed certificate and its key to dir and returns
+// their paths; the certificate doubles as a CA bundle.
+func writeCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildClientConfig(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeCert(t, dir)
+	notPEM := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		files     files
+		wantErr   bool
+		wantRoots bool
+		wantCert  bool
+	}{
+		{"system roots", files{serverName: "orders"}, false, false, false},
+		{"CA file", files{ca: cert}, false, true, false},
+		{"client cert", files{ca: cert, cert: cert, key: key}, false, true, true},
+		{"cert without key is not presented", files{cert: cert}, false, false, false},
+		{"missing CA file", files{ca: filepath.Join(dir, "missing.pem")}, true, false, false},
+		{"CA file without certificates", files{ca: notPEM}, true, false, false},
+		{"key not matching", files{cert: cert, key: notPEM}, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := BuildClientConfig(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildClientConfig() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.ServerName != tt.files.serverName {
+				t.Errorf("ServerName = %q, want %q", cfg.ServerName, tt.files.serverName)
+			}
+			if (cfg.RootCAs != nil) != tt.wantRoots {
+				t.Errorf("RootCAs set = %v, want %v", cfg.RootCAs != nil, tt.wantRoots)
+			}
+			if (len(cfg.Certificates) > 0) != tt.wantCert {
+				t.Errorf("client cert set = %v, want %v", len(cfg.Certificates) > 0, tt.wantCert)
+			}
+		})
+	}
+}
+
+func TestBuildServerConfig(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := writeCert(t, dir)
+
+	tests := []struct {
+		name           string
+		files          files
+		opts           []ServerOption
+		wantErr        bool
+		wantClientAuth tls.ClientAuthType
+		wantMinVersion uint16
+		wantClientCAs  bool
+	}{
+		{"defaults", files{cert: cert, key: key}, nil, false, tls.NoClientCert, tls.VersionTLS12, false},
+		{"CA file unused without verification", files{cert: cert, key: key, ca: cert}, []ServerOption{WithClientAuth(tls.RequestClientCert)}, false, tls.RequestClientCert, tls.VersionTLS12, false},
+		{"mTLS with CA file", files{cert: cert, key: key, ca: cert}, []ServerOption{WithClientAuth(tls.RequireAndVerifyClientCert)}, false, tls.RequireAndVerifyClientCert, tls.VersionTLS12, true},
+		{"mTLS with system roots", files{cert: cert, key: key}, []ServerOption{WithClientAuth(tls.VerifyClientCertIfGiven)}, false, tls.VerifyClientCertIfGiven, tls.VersionTLS12, true},
+		{"min version", files{cert: cert, key: key}, []ServerOption{WithMinVersion(tls.VersionTLS13)}, false, tls.NoClientCert, tls.VersionTLS13, false},
+		{"missing key", files{cert: cert}, nil, true, 0, 0, false},
+		{"bad CA file", files{cert: cert, key: key, ca: key}, []ServerOption{WithClientAuth(tls.RequireAndVerifyClientCert)}, true, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := BuildServerConfig(tt.files, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildServerConfig() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.ClientAuth != tt.wantClientAuth {
+				t.Errorf("ClientAuth = %v, want %v", cfg.ClientAuth, tt.wantClientAuth)
+			}
+			if cfg.MinVersion != tt.wantMinVersion {
+				t.Errorf("MinVersion = %x, want %x", cfg.MinVersion, tt.wantMinVersion)
+			}
+			if (cfg.ClientCAs != nil) != tt.wantClientCAs {
+				t.Errorf("ClientCAs set = %v, want %v", cfg.ClientCAs != nil, tt.wantClientCAs)
+			}
+			if len(cfg.Certificates) != 1 {
+				t.Errorf("%d certificates, want 1", len(cfg.Certificates))
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	modes := []struct {
+		in      string
+		want    tls.ClientAuthType
+		wantErr bool
+	}{
+		{"none", tls.NoClientCert, false},
+		{"Require_And_Verify", tls.RequireAndVerifyClientCert, false},
+		{"verify_if_given", tls.VerifyClientCertIfGiven, false},
+		{"mutual", 0, true},
+	}
+	for _, tt := range modes {
+		got, err := ParseClientAuth(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseClientAuth(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	versions := []struct {
+		in      string
+		want    uint16
+		wantErr bool
+	}{
+		{"1.2", tls.VersionTLS12, false},
+		{"1.3", tls.VersionTLS13, false},
+		{"TLS1.3", 0, true},
+	}
+	for _, tt := range versions {
+		got, err := ParseVersion(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %x, %v, want %x", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMutualTLSHandshake(t *testing.T) {
+	cert, key := writeCert(t, t.TempDir())
+	serverCfg, err := BuildServerConfig(files{cert: cert, key: key, ca: cert}, WithClientAuth(tls.RequireAndVerifyClientCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		client  files
+		wantErr bool
+	}{
+		{"client cert", files{cert: cert, key: key, ca: cert, serverName: "localhost"}, false},
+		{"no client cert", files{ca: cert, serverName: "localhost"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg, err := BuildClientConfig(tt.client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lis, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lis.Close()
+			go func() {
+				conn, err := lis.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Write([]byte("ok"))
+			}()
+
+			conn, err := tls.Dial("tcp", lis.Addr().String(), clientCfg)
+			if err == nil {
+				// TLS 1.3 reports a rejected client cert on the first read.
+				_, err = conn.Read(make([]byte, 2))
+				conn.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handshake error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}